@@ -0,0 +1,178 @@
+/*
+Copyright © 2025 Alexandro Cu alexandro.cuma@gmail.com
+*/
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/alexandrocuma/gommit/internal/config"
+	"github.com/alexandrocuma/gommit/internal/git"
+	"github.com/alexandrocuma/gommit/pkg/ai"
+	"github.com/alexandrocuma/gommit/pkg/changelog"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	changelogFormat     string
+	changelogVersion    string
+	changelogFile       string
+	changelogAIClassify bool
+	changelogNoSummary  bool
+)
+
+// changelogCmd represents the changelog command
+var changelogCmd = &cobra.Command{
+	Use:   "changelog",
+	Short: "Generate release notes from the commits between two revisions",
+	Long: `Generates release notes from the commits between two revisions, usually tags.
+
+			Commits are grouped by their Conventional Commit type, so the same commits
+			always produce the same groups. The AI only writes the summary of the
+			release and, with --ai-classify, sorts commits that are not Conventional Commits.
+
+			Formats:
+			• keepachangelog - Keep a Changelog sections (Added, Changed, Fixed...)
+			• github         - GitHub release markdown
+			• plain          - Plain text
+
+			Examples:
+				gommit changelog                          # Changes since the latest tag
+				gommit changelog --from v1.4.0 --to v1.5.0
+				gommit changelog --format github          # Release notes for a GitHub release
+				gommit changelog --version 1.5.0 --write  # Prepend to CHANGELOG.md
+				gommit changelog --no-summary             # Skip the AI summary`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		gitOps := &git.RealGitOperations{}
+		if !gitOps.IsGitRepository() {
+			return git.ErrNotRepository
+		}
+
+		if !slices.Contains(changelog.Formats, changelogFormat) {
+			return &usageError{fmt.Errorf("unsupported format %q, use one of: %s", changelogFormat, strings.Join(changelog.Formats, ", "))}
+		}
+
+		toRev := toRevision
+		if toRev == "" {
+			toRev = "HEAD"
+		}
+		fromRev := fromRevision
+		if fromRev == "" {
+			// Without an earlier tag the release covers the full history
+			tag, err := gitOps.GetLatestTag(toRev + "^")
+			if err == nil {
+				fromRev = tag
+			}
+		}
+		for _, rev := range []string{fromRev, toRev} {
+			if rev == "" {
+				continue
+			}
+			_, err := gitOps.ResolveRevision(rev)
+			if err != nil {
+				return &usageError{err}
+			}
+		}
+
+		version := changelogVersion
+		if version == "" {
+			version = "Unreleased"
+			if gitOps.IsTag(toRev) {
+				version = toRev
+			}
+		}
+
+		if fromRev == "" {
+			fmt.Printf("📊 No earlier tag found, collecting all commits up to '%s'...\n", toRev)
+		} else {
+			fmt.Printf("📊 Collecting commits from '%s' to '%s'...\n", fromRev, toRev)
+		}
+		commits, err := gitOps.GetReleaseCommits(fromRev, toRev)
+		if err != nil {
+			return err
+		}
+		if len(commits) == 0 {
+			return fmt.Errorf("%w between %s and %s", git.ErrNoChanges, fromRev, toRev)
+		}
+
+		// The AI is only needed for summaries and classifying free-form commits
+		var aiClient *ai.Client
+		if !changelogNoSummary || changelogAIClassify {
+			cfg, err := config.LoadConfig()
+			if err != nil {
+				return fmt.Errorf("failed to load configuration: %w", err)
+			}
+
+			err = cfg.ValidateAIConfig()
+			if err != nil {
+				return err
+			}
+
+			aiClient, err = ai.NewClient(cfg)
+			if err != nil {
+				return fmt.Errorf("failed to initialize AI client: %w", err)
+			}
+		}
+
+		var overrides map[string]string
+		if unclassified := changelog.Unclassified(commits); changelogAIClassify && len(unclassified) > 0 {
+			fmt.Printf("🧠 Classifying %d commits...\n", len(unclassified))
+			overrides, err = aiClient.ClassifyCommits(unclassified, changelog.CategoryKeys())
+			if err != nil {
+				return fmt.Errorf("error classifying commits: %w", err)
+			}
+		}
+
+		release := changelog.Release{
+			Version: version,
+			Date:    time.Now().Format("2006-01-02"),
+			Groups:  changelog.Classify(commits, overrides),
+		}
+
+		if !changelogNoSummary {
+			fmt.Println("🧠 Summarizing release...")
+			notes, err := changelog.Render(release, changelog.FormatGitHub)
+			if err != nil {
+				return err
+			}
+			release.Summary, err = aiClient.SummarizeRelease(notes)
+			if err != nil {
+				return fmt.Errorf("error summarizing release: %w", err)
+			}
+		}
+
+		notes, err := changelog.Render(release, changelogFormat)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println()
+		fmt.Println(notes)
+
+		if changelogFile != "" {
+			err = changelog.Prepend(changelogFile, notes)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("💾 Release notes added to: %s\n", changelogFile)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(changelogCmd)
+
+	changelogCmd.Flags().StringVar(&fromRevision, "from", "", "Revision to start from (default: latest tag, or the full history without one)")
+	changelogCmd.Flags().StringVar(&toRevision, "to", "", "Revision to end at (default: HEAD)")
+	changelogCmd.Flags().StringVarP(&changelogFormat, "format", "f", changelog.FormatKeepAChangelog, "Output format: keepachangelog, github or plain")
+	changelogCmd.Flags().StringVar(&changelogVersion, "version", "", "Version heading (default: --to when it is a tag, otherwise Unreleased)")
+	changelogCmd.Flags().StringVarP(&changelogFile, "write", "w", "", "Prepend the release notes to a changelog file")
+	changelogCmd.Flags().Lookup("write").NoOptDefVal = "CHANGELOG.md"
+	changelogCmd.Flags().BoolVar(&changelogAIClassify, "ai-classify", false, "Let the AI categorize commits that are not Conventional Commits")
+	changelogCmd.Flags().BoolVar(&changelogNoSummary, "no-summary", false, "Skip the AI-written release summary")
+}
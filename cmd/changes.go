@@ -0,0 +1,149 @@
+/*
+Copyright © 2025 Alexandro Cu alexandro.cuma@gmail.com
+*/
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/alexandrocuma/gommit/internal/config"
+	"github.com/alexandrocuma/gommit/internal/git"
+	"github.com/alexandrocuma/gommit/pkg/history"
+	"github.com/alexandrocuma/gommit/pkg/patch"
+
+	"github.com/spf13/cobra"
+)
+
+// diffFile is a patch file, or "-" for stdin, used instead of the repository
+var diffFile string
+
+// changeSet is what a PR description is generated from, read either from a
+// revision range of the repository or from a patch file
+type changeSet struct {
+	Branch      string
+	Base        string
+	Title       string   // Working title until the AI title is generated
+	Commits     []string // One line per commit, newest first
+	Details     []git.Commit
+	Diff        string
+	DiffStats   string
+	FileChanges []git.FileChange
+	History     string
+	Author      string
+}
+
+// branchChanges collects the changes between two revisions of the repository
+func branchChanges(cfg *config.Config, gitOps *git.RealGitOperations, fromRev, toRev string) (*changeSet, error) {
+	diff, err := gitOps.GetDiffBetweenBranches(fromRev, toRev)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get diff: %w", err)
+	}
+
+	commits, err := gitOps.GetCommitsBetweenBranches(fromRev, toRev)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit history: %w", err)
+	}
+
+	diffStats, err := gitOps.GetDiffStatsBetweenBranches(fromRev, toRev)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get diff stats: %w", err)
+	}
+
+	// Renames, mode changes, binary and LFS files
+	fileChanges, err := gitOps.GetFileChangesBetweenBranches(fromRev, toRev)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get file changes: %w", err)
+	}
+
+	details, err := gitOps.GetCommitDetailsBetweenBranches(fromRev, toRev)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit history: %w", err)
+	}
+
+	// Full commit messages and history of the changed files before this range
+	historyContext, err := history.Gather(gitOps, history.Request{
+		BranchBase: fromRev,
+		BranchHead: toRev,
+		FileRev:    fromRev,
+		Paths:      changedPaths(fileChanges),
+	}, cfg.History)
+	if err != nil {
+		logf("⚠️  Some commit history is missing from the context: %v\n", err)
+	}
+
+	title := generatePRTitle(toRev)
+	if toRev == "HEAD" && len(commits) > 0 && commits[0] != "" {
+		// Detached HEAD has no branch name, use the latest commit subject instead
+		_, title, _ = strings.Cut(commits[0], " ")
+	}
+
+	return &changeSet{
+		Branch:      toRev,
+		Base:        fromRev,
+		Title:       title,
+		Commits:     commits,
+		Details:     details,
+		Diff:        diff,
+		DiffStats:   diffStats,
+		FileChanges: fileChanges,
+		History:     historyContext,
+		Author:      gitOps.GetUserName(),
+	}, nil
+}
+
+// patchChanges collects the changes of a patch file. The commits are only
+// known when the patch was produced by git format-patch.
+func patchChanges(p *patch.Patch) *changeSet {
+	fileChanges := patch.FileChanges(p.Diff)
+	changes := &changeSet{
+		Title:       p.Title(),
+		Details:     p.Commits,
+		Diff:        p.Diff,
+		DiffStats:   patch.Stat(fileChanges),
+		FileChanges: fileChanges,
+	}
+
+	for _, commit := range p.Commits {
+		changes.Commits = append(changes.Commits, strings.TrimSpace(commit.ShortHash()+" "+commit.Subject))
+	}
+	if len(p.Commits) > 0 {
+		changes.Author = p.Commits[0].Author
+	}
+	if changes.Title == "" && diffFile != patch.Stdin {
+		name := filepath.Base(diffFile)
+		changes.Title = generatePRTitle(strings.TrimSuffix(name, filepath.Ext(name)))
+	}
+	return changes
+}
+
+// readPatch reads the patch given with --diff-file
+func readPatch() (*patch.Patch, error) {
+	source := diffFile
+	if source == patch.Stdin {
+		source = "stdin"
+	}
+	logf("📄 Reading patch from %s...\n", source)
+	p, err := patch.Read(diffFile)
+	if err != nil {
+		return nil, err
+	}
+	if len(p.Commits) > 0 {
+		logf("📬 Found %d patches\n", len(p.Commits))
+	}
+	return p, nil
+}
+
+// checkDiffFileFlags rejects flags that need the repository when --diff-file is given
+func checkDiffFileFlags(cmd *cobra.Command, names ...string) error {
+	if diffFile == "" {
+		return nil
+	}
+	for _, name := range names {
+		if cmd.Flags().Changed(name) {
+			return &usageError{fmt.Errorf("--%s cannot be used with --diff-file", name)}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+/*
+Copyright © 2025 Alexandro Cu alexandro.cuma@gmail.com
+*/
+package cmd
+
+import (
+	"os"
+	"strings"
+
+	"github.com/alexandrocuma/gommit/pkg/utils"
+
+	"github.com/manifoldco/promptui"
+	"golang.org/x/term"
+)
+
+var ciMode bool
+
+// nonInteractive reports whether prompts must be skipped: with --ci, when
+// CI=true as set by most CI services, or when stdin is not a terminal
+func nonInteractive() bool {
+	if ciMode || strings.EqualFold(os.Getenv("CI"), "true") {
+		return true
+	}
+	return !term.IsTerminal(int(os.Stdin.Fd()))
+}
+
+// confirm asks a yes/no question, answering no without asking when running non-interactively
+func confirm(label string) bool {
+	if nonInteractive() {
+		return false
+	}
+	prompt := promptui.Prompt{
+		Label:     label,
+		IsConfirm: true,
+	}
+	_, err := prompt.Run()
+	return err == nil
+}
+
+// offerClipboard copies content to the clipboard with --clipboard, otherwise
+// asks first unless prompts are skipped
+func offerClipboard(content, what string) {
+	if !copyToClipboard && (skipReview || !confirm("📄 Copy to clipboard")) {
+		return
+	}
+
+	err := utils.CopyToClipboardUtil(content)
+	if err != nil {
+		logf("⚠️  %v\n", err)
+		return
+	}
+	logf("📋 %s copied to clipboard!\n", what)
+}
@@ -5,7 +5,7 @@ package cmd
 
 import (
 	"fmt"
-	"log"
+	"strings"
 
 	"github.com/alexandrocuma/gommit/internal/config"
 	"github.com/alexandrocuma/gommit/internal/helpers"
@@ -36,10 +36,10 @@ var configCmd = &cobra.Command{
 			• Model settings (temperature, max tokens)
 			• Masked API key (showing last 4 characters)
 			• Config file location on disk`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		cfg, err := config.LoadConfig()
 		if err != nil {
-			log.Fatalf("❌ Failed to load configuration: %v", err)
+			return fmt.Errorf("failed to load configuration: %w", err)
 		}
 
 		fmt.Printf("\n")
@@ -57,7 +57,31 @@ var configCmd = &cobra.Command{
 		fmt.Printf("\n📁 Directory Settings:\n")
 		fmt.Printf("  Prompts:    %s\n", cfg.Directory.Prompts)
 		fmt.Printf("  Templates:  %s\n", cfg.Directory.Templates)
-	
+
+		fmt.Printf("\n📜 History Context:\n")
+		fmt.Printf("  Include Authors: %t\n", cfg.History.IncludeAuthors)
+		fmt.Printf("  File Commits:    %d\n", cfg.History.FileCommits)
+		fmt.Printf("  Max Tokens:      %d\n", cfg.History.MaxTokens)
+		fmt.Printf("  Examples:        %d\n", cfg.History.Examples)
+		fmt.Printf("  Example Tokens:  %d\n", cfg.History.ExampleTokens)
+
+		fmt.Printf("\n🌐 Forge Settings:\n")
+		fmt.Printf("  Provider:       %s\n", valueOrAuto(cfg.Forge.Provider))
+		fmt.Printf("  Remote:         %s\n", cfg.Forge.Remote)
+		fmt.Printf("  GitHub API URL: %s\n", valueOrAuto(cfg.Forge.GitHub.APIURL))
+		fmt.Printf("  GitHub Token:   %s\n", helpers.MaskAPIKey(cfg.Forge.GitHub.Token))
+		fmt.Printf("  GitLab API URL: %s\n", valueOrAuto(cfg.Forge.GitLab.APIURL))
+		fmt.Printf("  GitLab Token:   %s\n", helpers.MaskAPIKey(cfg.Forge.GitLab.Token))
+
+		fmt.Printf("\n🔀 Pull Requests:\n")
+		fmt.Printf("  Conventional Title: %t\n", cfg.PR.ConventionalTitle)
+		fmt.Printf("  Title Max Length:   %d\n", cfg.PR.TitleMaxLength)
+
+		fmt.Printf("\n🔎 Review:\n")
+		fmt.Printf("  Request Changes On: %s\n", cfg.Review.RequestChangesOn)
+		fmt.Printf("  Fail On:            %s\n", valueOr(cfg.Review.FailOn, "(never)"))
+		fmt.Printf("  Max Findings:       %d\n", cfg.Review.MaxFindings)
+		fmt.Printf("  Categories:         %s\n", valueOr(strings.Join(cfg.Review.Categories, ", "), "(all)"))
 
 		fmt.Printf("\n📄 Prompt Files:\n")
 		files, err := directory.ListFilesByExtension(cfg.Directory.Prompts, ".md", ".txt")
@@ -69,17 +93,39 @@ var configCmd = &cobra.Command{
 		}
 
 		fmt.Printf("\n📄 Template Files:\n")
-		files, err = directory.ListFilesByExtension(cfg.Directory.Templates, ".md", ".txt")
+		files, err = directory.ListFilesByExtension(cfg.Directory.Templates, ".md", ".txt", ".tmpl")
 		if err != nil {
 			fmt.Printf("❌ Failed to list template files: %v", err)
 		}
 		for _, file := range files {
-			fmt.Printf("  -  %s\n", file)
+			meta, err := directory.LoadTemplateMeta(cfg.Directory.Templates, file)
+			switch {
+			case err != nil:
+				fmt.Printf("  -  %s (⚠️  %v)\n", file, err)
+			case meta.Description != "":
+				fmt.Printf("  -  %s — %s\n", file, meta.Description)
+			default:
+				fmt.Printf("  -  %s\n", file)
+			}
 		}
 		fmt.Printf("\n")
+		return nil
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(configCmd)
 }
+
+// valueOrAuto displays empty settings that are detected at runtime
+func valueOrAuto(value string) string {
+	return valueOr(value, "(auto)")
+}
+
+// valueOr displays empty settings with what they mean, e.g. "(all)"
+func valueOr(value, empty string) string {
+	if value == "" {
+		return empty
+	}
+	return value
+}
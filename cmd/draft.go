@@ -5,19 +5,29 @@ package cmd
 
 import (
 	"fmt"
-	"log"
-	"os"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/alexandrocuma/gommit/internal/config"
 	"github.com/alexandrocuma/gommit/internal/git"
 	"github.com/alexandrocuma/gommit/pkg/ai"
-	"github.com/alexandrocuma/gommit/pkg/utils"
+	"github.com/alexandrocuma/gommit/pkg/directory"
+	"github.com/alexandrocuma/gommit/pkg/interactive"
+	"github.com/alexandrocuma/gommit/pkg/markdown"
+	"github.com/alexandrocuma/gommit/pkg/output"
+	"github.com/alexandrocuma/gommit/pkg/ticket"
+	"github.com/alexandrocuma/gommit/templates"
 
-	"github.com/manifoldco/promptui"
 	"github.com/spf13/cobra"
 )
 
+const (
+	autoTemplate    = "auto"                // Discover the template committed to the repository
+	builtinTemplate = "default"             // Skip discovery and use the default template
+	defaultTemplate = templates.DefaultName // Used when the repository has no template
+)
+
 var (
 	baseBranch      string
 	templateFile    string
@@ -36,129 +46,249 @@ var draftCmd = &cobra.Command{
 			Features:
 			• Compares current branch with any base branch
 			• Analyzes commits, diff stats, and code changes
-			• Uses customizable templates for structure
-			• Generates intelligent PR titles from branch names
+			• Uses the repository PR template (.github, docs, .gitlab) or your own templates
+			• Generates PR titles from the commits and description, keeping the branch ticket key
 			• Supports saving to file or clipboard
+			• Opens GitHub pull requests and GitLab merge requests directly with --create
+			• Updates the open pull request of the branch with --update, keeping
+			  sections edited by hand or marked with <!-- gommit:keep -->
 			• Works with your configured AI provider
 
 			Examples:
 				gommit draft                     # Compare with default base branch
 				gommit draft --base main         # Compare with main branch
 				gommit draft --base develop      # Compare with develop branch
+				gommit draft --from v1.4.0 --to v1.5.0 # Draft a release PR between tags
+				gommit draft --from HEAD~5       # Compare against any revision
 				gommit draft --title "My changes" # Use custom PR title
 				gommit draft --output pr.md      # Save to file
+				gommit draft --format json -o -  # Print the PR as JSON on stdout
+				gommit draft --format html -o pr.html # Save as HTML
+				gommit draft --template default  # Use default.md instead of the repository PR template
+				gommit draft --template hotfix   # Use a template from the templates directory
+				gommit draft --create --reviewer alice --label feature  # Open a GitHub pull request
+				gommit draft --draft-pr          # Open a draft pull request
+				gommit draft --create --squash --remove-source-branch  # Open a GitLab merge request
+				gommit draft --update            # Refresh the description of the open pull request
 
 			The generated PR description includes:
 			• Structured overview from template
 			• Summary of commits and changes
 			• File statistics and impact analysis
 			• Ready-to-use markdown content`,
-	Run: func(cmd *cobra.Command, args []string) {
-		// Load configuration
-		cfg, err := config.LoadConfig()
+	RunE: func(cmd *cobra.Command, args []string) error {
+		err := setupOutput(cmd, output.Formats)
 		if err != nil {
-			log.Fatalf("❌ Failed to load configuration: %v", err)
+			return err
 		}
 
-		cfg.ValidateAIConfig()
-
-		fmt.Println("🔍 Checking system requirements...")
-
-		// Check git
-		gitOps := &git.RealGitOperations{}
-		if !gitOps.IsGitRepository() {
-			fmt.Println("❌ Not a git repository")
-			os.Exit(1)
+		err = checkDiffFileFlags(cmd, "base", "from", "to", "create", "draft-pr", "update", "squash", "remove-source-branch", "reviewer", "label", "assignee")
+		if err != nil {
+			return err
 		}
 
-		// Get current branch
-		currentBranch, err := gitOps.GetCurrentBranch()
+		// Load configuration
+		cfg, err := config.LoadConfig()
 		if err != nil {
-			log.Fatalf("❌ Failed to get current branch: %v", err)
+			return fmt.Errorf("failed to load configuration: %w", err)
 		}
 
-		// Set default base branch if not provided
-		if baseBranch == "" {
-			baseBranch = gitOps.GetDefaultBaseBranch()
+		err = cfg.ValidateAIConfig()
+		if err != nil {
+			return err
 		}
 
-		fmt.Printf("📊 Comparing changes from '%s' to '%s'...\n", currentBranch, baseBranch)
+		gitOps := &git.RealGitOperations{}
+		var changes *changeSet
+		var prTarget *pullRequestTarget
+		var toRev string
+		if diffFile != "" {
+			p, err := readPatch()
+			if err != nil {
+				return err
+			}
+			changes = patchChanges(p)
+		} else {
+			logln("🔍 Checking system requirements...")
 
-		// Get diff between branches
-		diff, err := gitOps.GetDiffBetweenBranches(baseBranch, currentBranch)
-		if err != nil {
-			log.Fatalf("❌ Failed to get diff: %v", err)
+			// Check git
+			if !gitOps.IsGitRepository() {
+				return git.ErrNotRepository
+			}
+
+			// Resolve the revisions to compare
+			var fromRev string
+			fromRev, toRev, err = resolveRevisionRange(gitOps)
+			if err != nil {
+				return fmt.Errorf("failed to resolve revisions: %w", err)
+			}
+
+			logf("📊 Comparing changes from '%s' to '%s'...\n", describeRevision(gitOps, toRev), fromRev)
+
+			// Validate the pull request target before spending an AI request
+			if createPR || draftPR || updatePR {
+				prTarget, err = resolvePullRequestTarget(cfg, gitOps, fromRev, toRev)
+				if err != nil {
+					return err
+				}
+			}
+
+			changes, err = branchChanges(cfg, gitOps, fromRev, toRev)
+			if err != nil {
+				return err
+			}
 		}
 
-		// Get commit history
-		commits, err := gitOps.GetCommitsBetweenBranches(baseBranch, currentBranch)
-		if err != nil {
-			log.Fatalf("❌ Failed to get commit history: %v", err)
+		// Repository templates are written for people, they are used as written
+		repoTemplate := false
+		switch templateFile {
+		case builtinTemplate:
+			templateFile = defaultTemplate
+		case autoTemplate:
+			templateFile = defaultTemplate
+			// A patch may be drafted outside of any repository
+			if gitOps.IsGitRepository() {
+				templateFile, err = resolveAutoTemplate(gitOps)
+				if err != nil {
+					return err
+				}
+				repoTemplate = templateFile != defaultTemplate
+			}
 		}
 
-		// Get diff stats
-		diffStats, err := gitOps.GetDiffStatsBetweenBranches(baseBranch, currentBranch)
-		if err != nil {
-			log.Fatalf("❌ Failed to get diff stats: %v", err)
+		logf("📝 Using template: %s\n", templateFile)
+		logf("📄 Found %d commits with %d lines changed\n", len(changes.Commits), strings.Count(changes.Diff, "\n"))
+
+		// Branch-derived working title, replaced by an AI title after the description
+		customTitle := prTitle != ""
+		if !customTitle {
+			prTitle = changes.Title
 		}
 
-		fmt.Printf("📝 Using template: %s\n", templateFile)
-		fmt.Printf("📄 Found %d commits with %d lines changed\n", len(commits), strings.Count(diff, "\n"))
+		// Repository data for deterministic template parts such as file tables and ticket links
+		templateData := &ai.TemplateData{
+			Branch:       changes.Branch,
+			Base:         changes.Base,
+			Commits:      changes.Details,
+			DiffStats:    changes.DiffStats,
+			Author:       changes.Author,
+			Tickets:      commitTickets(changes.Branch, changes.Details),
+			ChangedFiles: ai.NewChangedFiles(changes.FileChanges),
+			Date:         time.Now(),
+		}
 
-		// Generate PR title if not provided
-		if prTitle == "" {
-			prTitle = generatePRTitle(currentBranch)
+		// When updating, the AI revises the current description for the new commits
+		var previous string
+		var newCommits []string
+		if prTarget != nil && prTarget.existing != nil {
+			previous = markdown.StripState(prTarget.existing.Body)
+			if state, ok := markdown.ReadState(prTarget.existing.Body); ok && state.Head != "" {
+				// The recorded head may be gone after a force push, the AI then compares all commits
+				newCommits, _ = gitOps.GetCommitsBetweenBranches(state.Head, toRev)
+			}
 		}
 
 		// Initialize AI client
-		fmt.Println("🧠 Generating PR description...")
+		logln("🧠 Generating PR description...")
 		aiClient, err := ai.NewClient(cfg)
 		if err != nil {
-			log.Fatalf("❌ Failed to initialize AI client: %v", err)
+			return fmt.Errorf("failed to initialize AI client: %w", err)
 		}
 
 		// Generate PR description using template
-		prDescription, err := aiClient.GeneratePRDescriptionWithTemplate(prTitle, commits, diff, diffStats, templateFile)
+		description, err := aiClient.GeneratePRDescriptionWithTemplate(ai.PRInput{
+			Title:         prTitle,
+			Commits:       changes.Commits,
+			Diff:          changes.Diff,
+			DiffStats:     changes.DiffStats,
+			FileChanges:   git.SummarizeFileChanges(changes.FileChanges),
+			History:       changes.History,
+			TemplateFile:  templateFile,
+			TemplateData:  templateData,
+			PlainTemplate: repoTemplate,
+			Previous:      previous,
+			NewCommits:    newCommits,
+		})
 		if err != nil {
-			log.Fatalf("❌ Error generating PR description: %v", err)
+			return fmt.Errorf("error generating PR description: %w", err)
+		}
+		prDescription := description.Body
+
+		if prTarget != nil && prTarget.existing != nil {
+			prDescription, prTarget.kept = markdown.Merge(prTarget.existing.Body, prDescription)
+			if len(prTarget.kept) > 0 {
+				logf("✋ Keeping manually edited sections: %s\n", strings.Join(prTarget.kept, ", "))
+			}
+			if !customTitle {
+				// Updates keep the current title unless --title is given
+				prTitle = prTarget.existing.Title
+			}
+		} else if !customTitle {
+			logln("🏷️  Generating PR title...")
+			title, err := aiClient.GeneratePRTitle(ai.PRTitleInput{
+				Branch:       changes.Branch,
+				Commits:      changes.Commits,
+				DiffStats:    changes.DiffStats,
+				Description:  prDescription,
+				Conventional: cfg.PR.ConventionalTitle,
+				MaxLength:    cfg.PR.TitleMaxLength,
+			})
+			if err != nil {
+				logf("⚠️  Could not generate a PR title, using '%s': %v\n", prTitle, err)
+			} else if title != "" {
+				prTitle = title
+			}
 		}
 
 		// Display results
-		fmt.Println("\n" + strings.Repeat("━", 60))
-		fmt.Println("📋 PR DESCRIPTION GENERATED")
-		fmt.Println(strings.Repeat("━", 60))
-		fmt.Printf("📌 Title: %s\n\n", prTitle)
-		fmt.Println(prDescription)
-		fmt.Println(strings.Repeat("━", 60))
+		if showBanner(cmd) {
+			fmt.Println("\n" + strings.Repeat("━", 60))
+			fmt.Println("📋 PR DESCRIPTION GENERATED")
+			fmt.Println(strings.Repeat("━", 60))
+			fmt.Printf("📌 Title: %s\n\n", prTitle)
+			fmt.Println(prDescription)
+			fmt.Println(strings.Repeat("━", 60))
+		}
 
 		// Handle output options
-		if outputFile != "" {
-			fullPRContent := fmt.Sprintf("# %s\n\n%s", prTitle, prDescription)
-			err := os.WriteFile(outputFile, []byte(fullPRContent), 0644)
+		err = writeDocument(cmd, output.NewDocument(prTitle, prDescription, description.Model, aiClient), "PR description")
+		if err != nil {
+			return err
+		}
+
+		if prTarget != nil && prTarget.existing != nil {
+			// Keep the current title unless a new one was given explicitly
+			title := ""
+			if customTitle {
+				title = prTitle
+			}
+			pr, err := updatePullRequest(gitOps, prTarget, title, prDescription)
 			if err != nil {
-				log.Fatalf("❌ Failed to write output file: %v", err)
+				return err
 			}
-			fmt.Printf("💾 PR description saved to: %s\n", outputFile)
+			logf("🎉 Pull request #%d updated: %s\n", pr.Number, pr.URL)
+			return nil
 		}
 
-		prompt := promptui.Prompt{
-			Label:     "📄 Copy to clipboard",
-			IsConfirm: true,
-		}
-		_, err = prompt.Run()
-		
-		if err != nil {
-			fmt.Println("\n🎉 PR description ready!")
-			return 
+		if prTarget != nil {
+			pr, err := publishPullRequest(gitOps, prTarget, prTitle, prDescription)
+			if pr == nil {
+				return err
+			}
+			logf("🎉 Pull request #%d opened: %s\n", pr.Number, pr.URL)
+			if err != nil {
+				logf("⚠️  %v\n", err)
+			}
+			return nil
 		}
-		
-		fullPRContent := fmt.Sprintf("# %s\n\n%s", prTitle, prDescription)
-		err = utils.CopyToClipboardUtil(fullPRContent)
-		if err == nil {
-			fmt.Println("📋 PR description copied to clipboard!")
+
+		// When stdout carries the document only copy it when asked to
+		if !toStdout(cmd) || copyToClipboard {
+			offerClipboard(fmt.Sprintf("# %s\n\n%s", prTitle, prDescription), "PR description")
 		}
 
-		fmt.Println("\n🎉 PR description ready!")
+		logln("\n🎉 PR description ready!")
+		return nil
 	},
 }
 
@@ -166,11 +296,70 @@ func init() {
 	rootCmd.AddCommand(draftCmd)
 
 	draftCmd.Flags().StringVarP(&baseBranch, "base", "b", "", "Base branch to compare against (default: main/master)")
-	draftCmd.Flags().StringVarP(&templateFile, "template", "t", "default.md", "Template name or path to template file")
-	draftCmd.Flags().StringVarP(&outputFile, "output", "o", "", "Output file to save PR description")
-	draftCmd.Flags().StringVarP(&prTitle, "title", "T", "", "PR title (default: auto-generated from branch name)")
-	draftCmd.Flags().BoolVar(&skipReview, "skip-review", false, "Skip interactive review and editing")
-	draftCmd.Flags().BoolVarP(&copyToClipboard, "clipboard", "c", false, "Copy PR description to clipboard")
+	draftCmd.Flags().StringVar(&fromRevision, "from", "", "Revision to compare from: branch, tag, SHA or HEAD~N (default: --base)")
+	draftCmd.Flags().StringVar(&toRevision, "to", "", "Revision to compare to (default: current branch or detached HEAD)")
+	draftCmd.Flags().StringVarP(&templateFile, "template", "t", autoTemplate, "Template name, path to template file, 'auto' to use the repository template or 'default' to skip it")
+	addOutputFlags(draftCmd, "PR description", output.Formats)
+	draftCmd.Flags().StringVar(&diffFile, "diff-file", "", "Describe a patch file or git format-patch series instead of the repository, '-' for stdin")
+	draftCmd.Flags().StringVarP(&prTitle, "title", "T", "", "PR title (default: AI-generated from the commits and description)")
+	draftCmd.Flags().BoolVar(&skipReview, "skip-review", false, "Do not prompt: use the first repository template and skip the clipboard question")
+	draftCmd.Flags().BoolVarP(&copyToClipboard, "clipboard", "c", false, "Copy the PR description to the clipboard without asking")
+	draftCmd.Flags().BoolVar(&createPR, "create", false, "Push the branch and open a pull request with the generated description")
+	draftCmd.Flags().BoolVar(&draftPR, "draft-pr", false, "Open the pull request as a draft (implies --create)")
+	draftCmd.Flags().BoolVar(&updatePR, "update", false, "Update the description of the open pull request for the branch")
+	draftCmd.Flags().BoolVar(&squashMR, "squash", false, "Squash commits when the GitLab merge request is merged")
+	draftCmd.Flags().BoolVar(&removeSourceBranch, "remove-source-branch", false, "Delete the source branch when the GitLab merge request is merged")
+	draftCmd.Flags().StringSliceVar(&prReviewers, "reviewer", nil, "Request reviews from these users when creating the pull request")
+	draftCmd.Flags().StringSliceVar(&prLabels, "label", nil, "Add labels when creating the pull request")
+	draftCmd.Flags().StringSliceVar(&prAssignees, "assignee", nil, "Assign users when creating the pull request")
+}
+
+// resolveAutoTemplate picks the pull request template committed to the repository,
+// asking when there are several, and falls back to the default template
+func resolveAutoTemplate(gitOps *git.RealGitOperations) (string, error) {
+	root, err := gitOps.GetTopLevel()
+	if err != nil {
+		return "", err
+	}
+
+	paths := directory.DiscoverRepoTemplates(root)
+	switch len(paths) {
+	case 0:
+		return defaultTemplate, nil
+	case 1:
+		return paths[0], nil
+	}
+
+	choices := make([]interactive.TemplateChoice, len(paths))
+	for i, path := range paths {
+		label, err := filepath.Rel(root, path)
+		if err != nil {
+			label = path
+		}
+		meta, _ := directory.LoadTemplateMeta(filepath.Dir(path), filepath.Base(path))
+		choices[i] = interactive.TemplateChoice{Path: path, Label: label, Description: meta.Description}
+	}
+
+	if skipReview || nonInteractive() {
+		logf("📝 Found %d repository templates, using %s\n", len(choices), choices[0].Label)
+		return paths[0], nil
+	}
+
+	path, err := interactive.SelectTemplate(choices)
+	if err != nil {
+		logf("⚠️  %v, using %s\n", err, choices[0].Label)
+		return paths[0], nil
+	}
+	return path, nil
+}
+
+// commitTickets collects the ticket references of the branch name and commit messages
+func commitTickets(branch string, commits []git.Commit) []string {
+	texts := []string{branch}
+	for _, commit := range commits {
+		texts = append(texts, commit.Subject, commit.Body)
+	}
+	return ticket.Extract(texts...)
 }
 
 func generatePRTitle(currentBranch string) string {
@@ -188,3 +377,18 @@ func generatePRTitle(currentBranch string) string {
 	return title
 }
 
+// changedPaths returns the paths of changed files that existed before the change
+func changedPaths(changes []git.FileChange) []string {
+	var paths []string
+	for _, change := range changes {
+		switch {
+		case change.Status == 'A':
+			continue
+		case change.OldPath != "":
+			paths = append(paths, change.OldPath)
+		default:
+			paths = append(paths, change.Path)
+		}
+	}
+	return paths
+}
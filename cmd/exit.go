@@ -0,0 +1,62 @@
+/*
+Copyright © 2025 Alexandro Cu alexandro.cuma@gmail.com
+*/
+package cmd
+
+import (
+	"errors"
+
+	"github.com/alexandrocuma/gommit/internal/config"
+	"github.com/alexandrocuma/gommit/internal/git"
+	"github.com/alexandrocuma/gommit/pkg/ai/providers"
+	"github.com/alexandrocuma/gommit/pkg/forge"
+	"github.com/alexandrocuma/gommit/pkg/review"
+)
+
+// Exit codes returned by gommit. They are part of the public interface,
+// keep them stable and in sync with the README.
+const (
+	ExitOK            = 0
+	ExitError         = 1
+	ExitUsage         = 2
+	ExitNotRepository = 3
+	ExitNoChanges     = 4
+	ExitConfigMissing = 5
+	ExitProviderAuth  = 6
+	ExitProviderQuota = 7
+	ExitReviewFailed  = 8
+)
+
+// usageError marks errors caused by invalid flags or arguments
+type usageError struct {
+	err error
+}
+
+func (e *usageError) Error() string { return e.err.Error() }
+func (e *usageError) Unwrap() error { return e.err }
+
+// exitCode maps an error returned by a command to its documented exit code
+func exitCode(err error) int {
+	var usageErr *usageError
+
+	switch {
+	case err == nil:
+		return ExitOK
+	case errors.As(err, &usageErr):
+		return ExitUsage
+	case errors.Is(err, git.ErrNotRepository):
+		return ExitNotRepository
+	case errors.Is(err, git.ErrNoChanges):
+		return ExitNoChanges
+	case errors.Is(err, config.ErrConfigMissing):
+		return ExitConfigMissing
+	case errors.Is(err, providers.ErrAuth), errors.Is(err, forge.ErrAuth):
+		return ExitProviderAuth
+	case errors.Is(err, providers.ErrQuota):
+		return ExitProviderQuota
+	case errors.Is(err, review.ErrGate):
+		return ExitReviewFailed
+	default:
+		return ExitError
+	}
+}
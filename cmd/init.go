@@ -5,7 +5,6 @@ package cmd
 
 import (
 	"fmt"
-	"log"
 
 	"github.com/alexandrocuma/gommit/internal/config"
 	"github.com/alexandrocuma/gommit/internal/helpers"
@@ -38,7 +37,11 @@ var initCmd = &cobra.Command{
 			• Parameter tuning (temperature, tokens)
 			• Config file creation in user directory
 			• Next steps guidance for using gommit`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if nonInteractive() {
+			return &usageError{fmt.Errorf("init is interactive, configure gommit with %s_* environment variables instead, e.g. %s_AI_API_KEY", config.EnvPrefix, config.EnvPrefix)}
+		}
+
 		if config.ConfigExists() {
 			fmt.Println("⚠️  Configuration file already exists!")
 			prompt := promptui.Prompt{
@@ -49,18 +52,18 @@ var initCmd = &cobra.Command{
 			_, err := prompt.Run()
 			if err != nil {
 				fmt.Println("Init cancelled.")
-				return
+				return nil
 			}
 		}
 
 		cfg, err := interactive.RunSetup()
 		if err != nil {
-			log.Fatalf("❌ Init failed: %v", err)
+			return fmt.Errorf("init failed: %w", err)
 		}
 
 		err = config.SaveConfig(cfg)
 		if err != nil {
-			log.Fatalf("❌ Failed to save configuration: %v", err)
+			return fmt.Errorf("failed to save configuration: %w", err)
 		}
 
 		configPath := helpers.GetConfigPath()
@@ -70,6 +73,7 @@ var initCmd = &cobra.Command{
 		fmt.Println("  gommit     		 # Generate commit messages")
 		fmt.Println("  gommit draft    # Generate a PR description")
 		fmt.Println("  gommit review   # Generate a PR review")
+		return nil
 	},
 }
 
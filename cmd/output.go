@@ -0,0 +1,88 @@
+/*
+Copyright © 2025 Alexandro Cu alexandro.cuma@gmail.com
+*/
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/alexandrocuma/gommit/pkg/output"
+
+	"github.com/spf13/cobra"
+)
+
+// stdoutOutput writes the generated content to stdout with --output -
+const stdoutOutput = "-"
+
+var outputFormat string
+
+// progress receives status messages. It is switched to stderr when the
+// generated content itself is written to stdout, so it can be piped cleanly.
+var progress io.Writer = os.Stdout
+
+func logf(format string, a ...any) {
+	fmt.Fprintf(progress, format, a...)
+}
+
+func logln(a ...any) {
+	fmt.Fprintln(progress, a...)
+}
+
+// setupOutput validates the output flags against the formats of the command
+// and moves progress messages to stderr when the content goes to stdout
+func setupOutput(cmd *cobra.Command, formats []string) error {
+	if !slices.Contains(formats, outputFormat) {
+		return &usageError{fmt.Errorf("unsupported format %q, use one of: %s", outputFormat, strings.Join(formats, ", "))}
+	}
+	if toStdout(cmd) {
+		progress = os.Stderr
+	}
+	return nil
+}
+
+// toStdout reports whether the document is written to stdout, with --output -
+// or when only --format is given
+func toStdout(cmd *cobra.Command) bool {
+	return outputFile == stdoutOutput || (outputFile == "" && cmd.Flags().Changed("format"))
+}
+
+// showBanner reports whether the decorated terminal display is shown, which is
+// the case unless a format was requested or the content goes to stdout
+func showBanner(cmd *cobra.Command) bool {
+	return outputFile != stdoutOutput && !cmd.Flags().Changed("format")
+}
+
+// writeDocument writes the document in the requested format to the output
+// file, to stdout with --output -, or to stdout when only --format is given
+func writeDocument(cmd *cobra.Command, doc *output.Document, label string) error {
+	if outputFile == "" && !cmd.Flags().Changed("format") {
+		return nil
+	}
+
+	content, err := output.Render(doc, outputFormat)
+	if err != nil {
+		return err
+	}
+
+	if toStdout(cmd) {
+		_, err = fmt.Fprint(os.Stdout, content)
+		return err
+	}
+
+	err = os.WriteFile(outputFile, []byte(content), 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write output file: %w", err)
+	}
+	logf("💾 %s saved to: %s\n", label, outputFile)
+	return nil
+}
+
+// addOutputFlags registers the shared output flags of draft and review
+func addOutputFlags(cmd *cobra.Command, what string, formats []string) {
+	cmd.Flags().StringVarP(&outputFile, "output", "o", "", fmt.Sprintf("Save the %s to a file, or '-' for stdout", what))
+	cmd.Flags().StringVarP(&outputFormat, "format", "f", output.FormatMarkdown, "Output format: "+strings.Join(formats, ", "))
+}
@@ -0,0 +1,135 @@
+/*
+Copyright © 2025 Alexandro Cu alexandro.cuma@gmail.com
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/alexandrocuma/gommit/internal/config"
+	"github.com/alexandrocuma/gommit/internal/git"
+	"github.com/alexandrocuma/gommit/pkg/forge"
+	"github.com/alexandrocuma/gommit/pkg/markdown"
+)
+
+var (
+	createPR           bool
+	draftPR            bool
+	updatePR           bool
+	squashMR           bool
+	removeSourceBranch bool
+	prReviewers        []string
+	prLabels           []string
+	prAssignees        []string
+)
+
+// pullRequestTarget holds the forge and branches a pull request is opened for
+type pullRequestTarget struct {
+	forge              forge.Forge
+	remote             string
+	head               string
+	base               string
+	existing           *forge.PullRequest // Open pull request being updated with --update
+	kept               []string           // Sections of the existing description preserved on update
+	squash             bool
+	removeSourceBranch bool
+}
+
+// resolvePullRequestTarget validates that a pull request can be opened for the
+// compared revisions before any AI request is made
+func resolvePullRequestTarget(cfg *config.Config, gitOps *git.RealGitOperations, fromRev, toRev string) (*pullRequestTarget, error) {
+	if toRev == "HEAD" {
+		return nil, &usageError{fmt.Errorf("cannot open a pull request from a detached HEAD, check out a branch or pass --to <branch>")}
+	}
+	if !gitOps.BranchExists(toRev) {
+		return nil, &usageError{fmt.Errorf("cannot open a pull request from %q, --to must be a local branch", toRev)}
+	}
+
+	remote := cfg.Forge.Remote
+	remoteURL, err := gitOps.GetRemoteURL(remote)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := forge.NewForge(&cfg.Forge, remoteURL)
+	if err != nil {
+		return nil, err
+	}
+
+	target := &pullRequestTarget{
+		forge:              f,
+		remote:             remote,
+		head:               toRev,
+		base:               strings.TrimPrefix(fromRev, remote+"/"),
+		squash:             squashMR || cfg.Forge.GitLab.Squash,
+		removeSourceBranch: removeSourceBranch || cfg.Forge.GitLab.RemoveSourceBranch,
+	}
+
+	if updatePR {
+		target.existing, err = f.FindPullRequest(context.Background(), toRev)
+		if err != nil {
+			return nil, err
+		}
+		if target.existing == nil {
+			return nil, &usageError{fmt.Errorf("no open pull request found for branch %q, use --create to open one", toRev)}
+		}
+	}
+
+	return target, nil
+}
+
+// publishPullRequest pushes the head branch when needed and opens the pull request
+func publishPullRequest(gitOps *git.RealGitOperations, target *pullRequestTarget, title, body string) (*forge.PullRequest, error) {
+	if !gitOps.IsBranchPushed(target.remote, target.head) {
+		logf("⬆️  Pushing '%s' to '%s'...\n", target.head, target.remote)
+		err := gitOps.PushBranch(target.remote, target.head)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	logf("🚀 Opening pull request on %s...\n", target.forge.Name())
+	return target.forge.CreatePullRequest(context.Background(), &forge.CreateRequest{
+		Title:     title,
+		Body:      stampDescription(gitOps, target, body),
+		Head:      target.head,
+		Base:      target.base,
+		Draft:     draftPR,
+		Reviewers: prReviewers,
+		Labels:    prLabels,
+		Assignees: prAssignees,
+
+		Squash:             target.squash,
+		RemoveSourceBranch: target.removeSourceBranch,
+	})
+}
+
+// updatePullRequest pushes new commits of the head branch and replaces the
+// description of the open pull request. The title is only changed when given.
+func updatePullRequest(gitOps *git.RealGitOperations, target *pullRequestTarget, title, body string) (*forge.PullRequest, error) {
+	if !gitOps.IsBranchPushed(target.remote, target.head) {
+		logf("⬆️  Pushing '%s' to '%s'...\n", target.head, target.remote)
+		err := gitOps.PushBranch(target.remote, target.head)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	logf("✏️  Updating pull request #%d on %s...\n", target.existing.Number, target.forge.Name())
+	return target.forge.UpdatePullRequest(context.Background(), target.existing.Number, &forge.UpdateRequest{
+		Title: title,
+		Body:  stampDescription(gitOps, target, body),
+	})
+}
+
+// stampDescription records the head commit and generated sections in the
+// description so the next --update can detect sections edited by hand
+func stampDescription(gitOps *git.RealGitOperations, target *pullRequestTarget, body string) string {
+	head, err := gitOps.ResolveRevision(target.head)
+	if err != nil {
+		return body
+	}
+	return markdown.Stamp(body, head, target.kept)
+}
@@ -5,17 +5,16 @@ package cmd
 
 import (
 	"fmt"
-	"log"
-	"os"
 	"strings"
 
 	"github.com/alexandrocuma/gommit/internal/config"
 	"github.com/alexandrocuma/gommit/internal/git"
 	"github.com/alexandrocuma/gommit/internal/helpers"
 	"github.com/alexandrocuma/gommit/pkg/ai"
-	"github.com/alexandrocuma/gommit/pkg/utils"
+	"github.com/alexandrocuma/gommit/pkg/output"
+	"github.com/alexandrocuma/gommit/pkg/patch"
+	"github.com/alexandrocuma/gommit/pkg/review"
 
-	"github.com/manifoldco/promptui"
 	"github.com/spf13/cobra"
 )
 
@@ -39,87 +38,165 @@ var reviewCmd = &cobra.Command{
 			gommit review                   # Compare with default base branch
 			gommit review --base main       # Compare with main branch
 			gommit review --base develop    # Compare with develop branch
+			gommit review --from v1.4.0 --to v1.5.0  # Review a tag range
+			gommit review --from origin/main --to HEAD  # Review a CI merge ref
+			gommit review --format json --output -      # Print the review as JSON
+			gommit review --diff-file fix.patch         # Review a patch from a mailing list
+			git format-patch -3 --stdout | gommit review --diff-file -
+			gommit review --pr 42 --post                # Comment on GitHub pull request #42
+			gommit review --format sarif --output review.sarif  # Export for code scanning
+			gommit review --fail-on high                # Exit with code 8 on high or critical findings
+			gommit review --category bug,security --max-findings 5  # Gate on bugs and security only
+
+		The review lists findings grouped by severity (critical, high, medium, low, info).
+		Each finding has:
+		• File and line range
+		• Category: bug, security, performance, style or test
+		• Explanation of the issue
+		• Suggested fix
+
+		A summary table counts the findings by severity and category. With --fail-on
+		or --max-findings the command exits with code 8 when the findings exceed the
+		threshold, after the review is written and posted, so it can gate a pipeline.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		err := setupOutput(cmd, output.ReviewFormats)
+		if err != nil {
+			return err
+		}
+
+		err = checkDiffFileFlags(cmd, "base", "from", "to", "post", "pr")
+		if err != nil {
+			return err
+		}
+		if postReview && reviewPRNumber <= 0 {
+			return &usageError{fmt.Errorf("--post requires --pr <number>")}
+		}
+		// Posted findings must point at lines of the pull request diff
+		if postReview {
+			for _, name := range []string{"base", "from", "to"} {
+				if cmd.Flags().Changed(name) {
+					return &usageError{fmt.Errorf("--post reviews the pull request diff and cannot be combined with --%s", name)}
+				}
+			}
+		}
 
-		The generated PR description includes:
-		• Overview of changes
-		• Code analysis and impact
-		• Potential issues or improvements
-		• Ready-to-use PR description text`,
-	Run: func(cmd *cobra.Command, args []string) {
 		// Load configuration
 		cfg, err := config.LoadConfig()
 		if err != nil {
-			log.Fatalf("❌ Failed to load configuration: %v", err)
+			return fmt.Errorf("failed to load configuration: %w", err)
 		}
 
-		cfg.ValidateAIConfig()
-
-		fmt.Println("🔍 Checking system requirements...")
-
-		// Check git
-		gitOps := &git.RealGitOperations{}
-
-		if !gitOps.IsGitRepository() {
-			fmt.Println("❌ Not a git repository")
-			os.Exit(1)
-		}
-		// Get current branch
-		currentBranch, err := gitOps.GetCurrentBranch()
+		err = applyReviewGate(cmd, cfg)
 		if err != nil {
-			log.Fatalf("❌ Failed to get current branch: %v", err)
+			return err
 		}
 
-		// Set default base branch if not provided
-		if baseBranch == "" {
-			baseBranch = gitOps.GetDefaultBaseBranch()
+		err = cfg.ValidateAIConfig()
+		if err != nil {
+			return err
 		}
 
-		fmt.Printf("📊 Comparing changes from '%s' to '%s'...\n", currentBranch, baseBranch)
-
-		// Get diff between branches
-		diff, err := gitOps.GetDiffBetweenBranches(baseBranch, currentBranch)
-		if err != nil {
-			log.Fatalf("❌ Failed to get diff: %v", err)
+		var diff string
+		var target *reviewTarget
+		if diffFile != "" {
+			p, err := readPatch()
+			if err != nil {
+				return err
+			}
+			diff = p.Diff
+		} else {
+			logln("🔍 Checking system requirements...")
+
+			// Check git
+			gitOps := &git.RealGitOperations{}
+
+			if !gitOps.IsGitRepository() {
+				return git.ErrNotRepository
+			}
+
+			// The pull request decides the revisions unless they are given
+			if reviewPRNumber > 0 {
+				target, err = resolveReviewTarget(cfg, gitOps)
+				if err != nil {
+					return err
+				}
+			}
+
+			// Resolve the revisions to compare
+			fromRev, toRev, err := resolveRevisionRange(gitOps)
+			if err != nil {
+				return fmt.Errorf("failed to resolve revisions: %w", err)
+			}
+
+			logf("📊 Comparing changes from '%s' to '%s'...\n", describeRevision(gitOps, toRev), fromRev)
+
+			// Get diff between branches
+			diff, err = gitOps.GetDiffBetweenBranches(fromRev, toRev)
+			if err != nil {
+				return fmt.Errorf("failed to get diff: %w", err)
+			}
 		}
 
 		// Initialize AI client
-		fmt.Println("🧠 Generating PR review...")
+		logln("🧠 Generating PR review...")
 		aiClient, err := ai.NewClient(cfg)
 		if err != nil {
-			log.Fatalf("❌ Failed to initialize AI client: %v", err)
+			return fmt.Errorf("failed to initialize AI client: %w", err)
 		}
 
-		// Generate PR description using template
-		prReview, err := aiClient.GeneratePRReview(diff)
+		// Generate the structured review
+		report, err := aiClient.GenerateReview(diff)
 		if err != nil {
-			log.Fatalf("❌ Error generating PR description: %v", err)
+			return fmt.Errorf("error generating PR review: %w", err)
 		}
+		report.Filter(cfg.Review.Categories)
 
-		out, err := helpers.RenderMarkdown(prReview)
-    if err != nil {
-      out = prReview
-    }
+		// Point findings at the changed lines they are about
+		if unanchored := report.Anchor(patch.NewLines(diff)); unanchored > 0 {
+			logf("⚠️  %d of %d findings do not match a changed line\n", unanchored, len(report.Findings))
+		}
+		prReview := report.Markdown()
 
 		// Display results
-		fmt.Println("\n" + strings.Repeat("━", 60))
-    fmt.Print(out)
-		fmt.Println(strings.Repeat("━", 60))
-
-		prompt := promptui.Prompt{
-			Label:     "📄 Copy to clipboard",
-			IsConfirm: true,
+		if showBanner(cmd) {
+			out, err := helpers.RenderMarkdown(prReview)
+			if err != nil {
+				out = prReview
+			}
+
+			fmt.Println("\n" + strings.Repeat("━", 60))
+			fmt.Print(out)
+			fmt.Println(strings.Repeat("━", 60))
 		}
-		_, err = prompt.Run()
-		
+
+		doc := output.NewDocument("", prReview, aiClient.Model(), aiClient)
+		doc.Review = report
+		err = writeDocument(cmd, doc, "PR review")
 		if err != nil {
-			fmt.Println("\n🎉 PR description ready!")
-			return 
+			return err
+		}
+
+		if postReview {
+			err = postFindings(cfg, target, report)
+			if err != nil {
+				return err
+			}
+		}
+
+		// When stdout carries the document only copy it when asked to
+		if !toStdout(cmd) || copyToClipboard {
+			offerClipboard(prReview, "PR review")
 		}
-		
-		err = utils.CopyToClipboardUtil(prReview)
-		if err == nil {
-			fmt.Println("📋 PR description copied to clipboard!")
+
+		logf("\n📋 Findings:\n%s", report.SummaryTable())
+
+		// Fail the pipeline once everything is written and posted
+		err = report.Gate(cfg.Review.FailOn, cfg.Review.MaxFindings)
+		if err != nil {
+			return err
 		}
+		logln("\n🎉 PR review ready!")
+		return nil
 	},
 }
 
@@ -127,4 +204,15 @@ func init() {
 	rootCmd.AddCommand(reviewCmd)
 
 	reviewCmd.Flags().StringVarP(&baseBranch, "base", "b", "", "Base branch to compare against (default: main/master/production)")
+	reviewCmd.Flags().StringVar(&fromRevision, "from", "", "Revision to compare from: branch, tag, SHA or HEAD~N (default: --base)")
+	reviewCmd.Flags().StringVar(&toRevision, "to", "", "Revision to compare to (default: current branch or detached HEAD)")
+	addOutputFlags(reviewCmd, "PR review", output.ReviewFormats)
+	reviewCmd.Flags().StringVar(&diffFile, "diff-file", "", "Review a patch file or git format-patch series instead of the repository, '-' for stdin")
+	reviewCmd.Flags().BoolVar(&postReview, "post", false, "Post the findings as a review with inline comments on the pull request given with --pr")
+	reviewCmd.Flags().IntVar(&reviewPRNumber, "pr", 0, "Pull request to review, its diff is used unless --from or --to is given")
+	reviewCmd.Flags().StringVar(&failOn, "fail-on", "", "Exit with code 8 when a finding is at or above this severity: "+strings.Join(review.Severities, ", "))
+	reviewCmd.Flags().IntVar(&maxFindings, "max-findings", 0, "Exit with code 8 when there are more findings than this, 0 for no limit")
+	reviewCmd.Flags().StringSliceVar(&reviewCategories, "category", nil, "Only report findings of these categories: "+strings.Join(review.Categories, ", "))
+	reviewCmd.Flags().BoolVar(&skipReview, "skip-review", false, "Do not prompt to copy the review to the clipboard")
+	reviewCmd.Flags().BoolVarP(&copyToClipboard, "clipboard", "c", false, "Copy the PR review to the clipboard without asking")
 }
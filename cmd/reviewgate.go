@@ -0,0 +1,54 @@
+/*
+Copyright © 2025 Alexandro Cu alexandro.cuma@gmail.com
+*/
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/alexandrocuma/gommit/internal/config"
+	"github.com/alexandrocuma/gommit/pkg/review"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	failOn           string
+	maxFindings      int
+	reviewCategories []string
+)
+
+// applyReviewGate overrides the review gate settings of the configuration
+// with the flags given and validates them before any AI request is made
+func applyReviewGate(cmd *cobra.Command, cfg *config.Config) error {
+	flags := cmd.Flags()
+	if flags.Changed("fail-on") {
+		cfg.Review.FailOn = failOn
+	}
+	if flags.Changed("max-findings") {
+		cfg.Review.MaxFindings = maxFindings
+	}
+	if flags.Changed("category") {
+		cfg.Review.Categories = reviewCategories
+	}
+
+	var problems []string
+	if cfg.Review.FailOn != "" && review.SeverityRank(cfg.Review.FailOn) < 0 {
+		problems = append(problems, fmt.Sprintf("invalid severity %q for --fail-on, use one of: %s", cfg.Review.FailOn, strings.Join(review.Severities, ", ")))
+	}
+	if cfg.Review.MaxFindings < 0 {
+		problems = append(problems, fmt.Sprintf("invalid --max-findings %d, use 0 for no limit", cfg.Review.MaxFindings))
+	}
+	for _, category := range cfg.Review.Categories {
+		if !slices.Contains(review.Categories, category) {
+			problems = append(problems, fmt.Sprintf("invalid category %q for --category, use one of: %s", category, strings.Join(review.Categories, ", ")))
+		}
+	}
+
+	if len(problems) > 0 {
+		return &usageError{fmt.Errorf("%s", strings.Join(problems, "; "))}
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+/*
+Copyright © 2025 Alexandro Cu alexandro.cuma@gmail.com
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/alexandrocuma/gommit/internal/config"
+	"github.com/alexandrocuma/gommit/internal/git"
+	"github.com/alexandrocuma/gommit/pkg/forge"
+	"github.com/alexandrocuma/gommit/pkg/review"
+)
+
+var (
+	postReview     bool
+	reviewPRNumber int
+)
+
+// findingMarker tags posted comments with the fingerprint of their finding,
+// so re-runs do not post the same finding twice
+var findingMarker = regexp.MustCompile(`<!-- gommit:finding ([0-9a-f]+) -->`)
+
+// reviewTarget is the pull request a review is posted to
+type reviewTarget struct {
+	reviewer forge.Reviewer
+	pr       *forge.PullRequest
+}
+
+// resolveReviewTarget loads the pull request given with --pr. Without --from
+// and --to the review covers the pull request diff, from the merge base of
+// the base branch to the head commit, so findings land on lines of that diff.
+func resolveReviewTarget(cfg *config.Config, gitOps *git.RealGitOperations) (*reviewTarget, error) {
+	remoteURL, err := gitOps.GetRemoteURL(cfg.Forge.Remote)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := forge.NewForge(&cfg.Forge, remoteURL)
+	if err != nil {
+		return nil, err
+	}
+	reviewer, ok := f.(forge.Reviewer)
+	if !ok {
+		return nil, &usageError{fmt.Errorf("posting reviews is not supported on %s", f.Name())}
+	}
+
+	pr, err := reviewer.GetPullRequest(context.Background(), reviewPRNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	if fromRevision == "" && toRevision == "" && baseBranch == "" {
+		_, err = gitOps.ResolveRevision(pr.HeadSHA)
+		if err != nil {
+			return nil, fmt.Errorf("head commit %s of pull request #%d is not available locally, run 'git fetch %s pull/%d/head'",
+				pr.HeadSHA, pr.Number, cfg.Forge.Remote, pr.Number)
+		}
+		fromRevision, err = gitOps.GetMergeBase(cfg.Forge.Remote+"/"+pr.Base, pr.HeadSHA)
+		if err != nil {
+			return nil, err
+		}
+		toRevision = pr.HeadSHA
+	}
+
+	return &reviewTarget{reviewer: reviewer, pr: pr}, nil
+}
+
+// postFindings submits the findings as one pull request review. Anchored
+// findings become inline comments, the others are listed in the review body.
+// Findings posted by a previous run are skipped. Changes are requested when a
+// finding reaches the configured severity.
+func postFindings(cfg *config.Config, target *reviewTarget, report *review.Report) error {
+	threshold := review.SeverityRank(cfg.Review.RequestChangesOn)
+	if threshold < 0 && cfg.Review.RequestChangesOn != "" && cfg.Review.RequestChangesOn != "none" {
+		return fmt.Errorf("invalid review.request_changes_on %q, use one of: %s, none", cfg.Review.RequestChangesOn, strings.Join(review.Severities, ", "))
+	}
+
+	ctx := context.Background()
+	reviews, err := target.reviewer.ListReviews(ctx, target.pr.Number)
+	if err != nil {
+		return err
+	}
+	comments, err := target.reviewer.ListReviewComments(ctx, target.pr.Number)
+	if err != nil {
+		return err
+	}
+
+	// Inline comments and the review bodies listing unanchored findings
+	posted := make(map[string]bool)
+	bodies := make([]string, 0, len(reviews)+len(comments))
+	for _, r := range reviews {
+		bodies = append(bodies, r.Body)
+	}
+	for _, c := range comments {
+		bodies = append(bodies, c.Body)
+	}
+	for _, body := range bodies {
+		for _, m := range findingMarker.FindAllStringSubmatch(body, -1) {
+			posted[m[1]] = true
+		}
+	}
+
+	event := forge.ReviewEventComment
+	var inline []forge.ReviewComment
+	var unanchored []review.Finding
+	duplicates := 0
+	for _, finding := range report.Findings {
+		fingerprint := finding.Fingerprint()
+		if posted[fingerprint] {
+			duplicates++
+			continue
+		}
+		if threshold >= 0 && review.SeverityRank(finding.Severity) <= threshold {
+			event = forge.ReviewEventRequestChanges
+		}
+		if !finding.Anchored {
+			unanchored = append(unanchored, finding)
+			continue
+		}
+		inline = append(inline, forge.ReviewComment{
+			Path:      finding.File,
+			StartLine: finding.StartLine,
+			Line:      finding.EndLine,
+			Body:      fmt.Sprintf("%s\n<!-- gommit:finding %s -->", finding.Comment(), fingerprint),
+		})
+	}
+
+	if duplicates > 0 {
+		logf("♻️  Skipping %d findings posted before\n", duplicates)
+	}
+	if len(inline) == 0 && len(unanchored) == 0 {
+		logf("✅ No new findings to post on pull request #%d\n", target.pr.Number)
+		return nil
+	}
+
+	logf("💬 Posting review on pull request #%d...\n", target.pr.Number)
+	err = target.reviewer.CreateReview(ctx, target.pr.Number, &forge.Review{
+		CommitSHA: target.pr.HeadSHA,
+		Body:      reviewBody(report.Summary, unanchored),
+		Event:     event,
+		Comments:  inline,
+	})
+	if err != nil {
+		return err
+	}
+
+	logf("🎉 Posted %d inline comments (%s): %s\n", len(inline), event, target.pr.URL)
+	return nil
+}
+
+// reviewBody is the summary of a posted review, with the findings that could
+// not be placed on a changed line
+func reviewBody(summary string, unanchored []review.Finding) string {
+	var b strings.Builder
+	b.WriteString("## 🤖 gommit review\n")
+	if summary != "" {
+		fmt.Fprintf(&b, "\n%s\n", strings.TrimSpace(summary))
+	}
+	if len(unanchored) > 0 {
+		b.WriteString("\n### Findings outside the changed lines\n")
+		for _, finding := range unanchored {
+			fmt.Fprintf(&b, "\n**`%s`**\n\n%s<!-- gommit:finding %s -->\n", finding.Location(), finding.Comment(), finding.Fingerprint())
+		}
+	}
+	return b.String()
+}
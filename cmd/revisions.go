@@ -0,0 +1,59 @@
+/*
+Copyright © 2025 Alexandro Cu alexandro.cuma@gmail.com
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/alexandrocuma/gommit/internal/git"
+)
+
+var (
+	fromRevision string
+	toRevision   string
+)
+
+// resolveRevisionRange determines the revisions to compare for draft and review.
+// --from falls back to --base and then to the default base branch, --to falls
+// back to the current branch or HEAD when detached. Both must resolve to commits.
+func resolveRevisionRange(gitOps *git.RealGitOperations) (string, string, error) {
+	from := fromRevision
+	if from == "" {
+		from = baseBranch
+	}
+	if from == "" {
+		from = gitOps.GetDefaultBaseBranch()
+	}
+
+	to := toRevision
+	if to == "" {
+		head, err := gitOps.GetHeadRevision()
+		if err != nil {
+			return "", "", err
+		}
+		to = head
+	}
+
+	for _, rev := range []string{from, to} {
+		_, err := gitOps.ResolveRevision(rev)
+		if err != nil {
+			return "", "", fmt.Errorf("cannot compare %s..%s: %w", from, to, err)
+		}
+	}
+
+	return from, to, nil
+}
+
+// describeRevision returns a human readable label for a revision,
+// showing the abbreviated SHA for a detached HEAD
+func describeRevision(gitOps *git.RealGitOperations, rev string) string {
+	if rev != "HEAD" {
+		return rev
+	}
+	short, err := gitOps.ShortRevision(rev)
+	if err != nil {
+		return rev
+	}
+	return fmt.Sprintf("HEAD (detached at %s)", short)
+}
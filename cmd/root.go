@@ -4,22 +4,27 @@ Copyright © 2025 Alexandro Cu alexandro.cuma@gmail.com
 package cmd
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
-	"log"
 	"os"
 	"strings"
 
 	"github.com/alexandrocuma/gommit/internal/config"
 	"github.com/alexandrocuma/gommit/internal/git"
 	"github.com/alexandrocuma/gommit/pkg/ai"
+	"github.com/alexandrocuma/gommit/pkg/history"
+	"github.com/alexandrocuma/gommit/pkg/patch"
+	"github.com/alexandrocuma/gommit/pkg/style"
 
-	"github.com/manifoldco/promptui"
 	"github.com/spf13/cobra"
 )
 
 var (
-	skipConfirm bool
-	verbose     bool
+	skipConfirm      bool
+	verbose          bool
+	stageAll         bool
+	includeUntracked bool
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -39,36 +44,69 @@ var rootCmd = &cobra.Command{
 		• Configurable base branch comparison
 
 		Examples:
-			git add . && gommit       # Commit all staged changes
-			gommit --verbose          # Show detailed process
-			gommit --no-confirm       # Skip confirmation prompt
-			gommit --base main        # Compare against main branch`,
-	Run: func(cmd *cobra.Command, args []string) {
+			git add . && gommit            # Commit all staged changes
+			gommit -a                      # Stage tracked modifications and commit them
+			gommit -- cmd/ README.md       # Commit only the given paths from the working tree
+			gommit -a --include-untracked  # Also add untracked files to the commit
+			gommit --verbose               # Show detailed process
+			gommit --yes                   # Skip confirmation prompt
+			gommit --ci --yes              # Commit without any prompt, e.g. in CI
+			gommit --diff-file fix.patch   # Only suggest a message for a patch file
+
+		Exit codes:
+			0 success, 1 unexpected error, 2 invalid usage, 3 not a git repository,
+			4 no changes, 5 configuration missing, 6 provider authentication failed,
+			7 provider quota exceeded, 8 review findings above --fail-on or --max-findings`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		// Positional arguments are only accepted as pathspecs after "--"
+		if len(args) > 0 && cmd.ArgsLenAtDash() != 0 {
+			return &usageError{fmt.Errorf("unknown command %q for %q", args[0], cmd.CommandPath())}
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		paths := args
+
+		err := checkDiffFileFlags(cmd, "all", "include-untracked", "yes")
+		if err != nil {
+			return err
+		}
+		if diffFile != "" && len(paths) > 0 {
+			return &usageError{fmt.Errorf("paths cannot be used with --diff-file")}
+		}
+
 		// Load configuration
 		cfg, err := config.LoadConfig()
 		if err != nil {
-			log.Fatalf("❌ Failed to load configuration: %v", err)
+			return fmt.Errorf("failed to load configuration: %w", err)
 		}
 
-		cfg.ValidateAIConfig()
+		err = cfg.ValidateAIConfig()
+		if err != nil {
+			return err
+		}
 
 		if verbose {
 			fmt.Printf("🤖 Using AI provider: %s\n", cfg.AI.Provider)
 		}
 
+		// Message-only mode, there is nothing to commit from a patch file
+		if diffFile != "" {
+			return patchCommitMessage(cfg)
+		}
+
 		// Initialize git operations
 		gitOps := &git.RealGitOperations{}
 
 		// Check if we're in a git repository
 		if !gitOps.IsGitRepository() {
-			fmt.Println("❌ Not a git repository")
-			os.Exit(1)
+			return git.ErrNotRepository
 		}
 
 		// Get current branch
 		currentBranch, err := gitOps.GetCurrentBranch()
 		if err != nil {
-			log.Fatalf("❌ Failed to get current branch: %v", err)
+			return fmt.Errorf("failed to get current branch: %w", err)
 		}
 
 		// Set default base branch if not provided
@@ -78,13 +116,45 @@ var rootCmd = &cobra.Command{
 
 		fmt.Printf("📊 Comparing changes from '%s' to '%s'...\n", currentBranch, baseBranch)
 
-		if verbose {
-			fmt.Println("📊 Analyzing staged changes...")
-		}
+		// Commit from the working tree when staging all or limiting to paths
+		fromWorkingTree := stageAll || len(paths) > 0
 
-		diff, err := gitOps.GetStagedDiff()
+		var diff string
+		var fileChanges []git.FileChange
+		if fromWorkingTree {
+			if verbose {
+				fmt.Println("📊 Analyzing working tree changes...")
+			}
+			diff, err = gitOps.GetWorkingTreeDiff(paths)
+			if err == nil {
+				fileChanges, err = gitOps.GetWorkingTreeFileChanges(paths)
+			}
+		} else {
+			if verbose {
+				fmt.Println("📊 Analyzing staged changes...")
+			}
+			diff, err = gitOps.GetStagedDiff()
+			if err == nil {
+				fileChanges, err = gitOps.GetStagedFileChanges()
+			}
+		}
 		if err != nil {
-			log.Fatalf("❌ Error getting git diff: %v", err)
+			return fmt.Errorf("error getting git diff: %w", err)
+		}
+
+		var untracked []string
+		if includeUntracked {
+			untracked, err = gitOps.GetUntrackedFiles(paths)
+			if err != nil {
+				return fmt.Errorf("error listing untracked files: %w", err)
+			}
+		}
+
+		if diff == "" && len(untracked) == 0 {
+			if fromWorkingTree {
+				return fmt.Errorf("%w in the working tree", git.ErrNoChanges)
+			}
+			return fmt.Errorf("%w: please stage your changes first (git add <files>) or commit tracked modifications directly (gommit -a)", git.ErrNoChanges)
 		}
 
 		// Get context for better commit messages
@@ -99,9 +169,48 @@ var rootCmd = &cobra.Command{
 			context = append(context, "Recent commits: "+strings.Join(recentCommits, ", "))
 		}
 
+		// Full messages of the branch commits and recent history of the changed files
+		historyReq := history.Request{FileRev: "HEAD", Paths: changedPaths(fileChanges)}
+		if base := gitOps.GetDefaultBaseBranch(); branch != "" && branch != base {
+			historyReq.BranchBase = base
+			historyReq.BranchHead = branch
+		}
+		historyContext, err := history.Gather(gitOps, historyReq, cfg.History)
+		if err != nil && verbose {
+			fmt.Printf("⚠️  Some commit history is missing from the context: %v\n", err)
+		}
+
+		// Past commits that touched the same files or packages, used as few-shot examples
+		examples := history.SelectExamples(gitOps, historyReq.Paths, cfg.History)
+
+		// Learned repository commit style, if 'gommit style learn' was run
+		var styleSection string
+		gitDir, err := gitOps.GetCommonGitDir()
+		if err == nil {
+			// A broken profile must not block committing, it is ignored until learned again
+			profile, err := style.Load(style.ProfilePath(gitDir))
+			if err != nil && verbose {
+				fmt.Printf("⚠️  Ignoring the repository style profile: %v\n", err)
+			}
+			styleSection = profile.PromptSection()
+		}
+
+		if len(untracked) > 0 {
+			context = append(context, "New untracked files included in this commit: "+summarizeUntrackedFiles(untracked))
+		}
+
 		if verbose {
+			if styleSection != "" {
+				fmt.Println("🎨 Using the repository style profile")
+			}
+			if len(examples) > 0 {
+				fmt.Printf("📚 Using %d similar past commits as examples\n", len(examples))
+			}
 			fmt.Printf("📁 Current branch: %s\n", branch)
 			fmt.Printf("📄 Staged changes: %d lines\n", strings.Count(diff, "\n"))
+			if len(untracked) > 0 {
+				fmt.Printf("🆕 Untracked files: %d\n", len(untracked))
+			}
 		}
 
 		// Initialize AI client
@@ -111,49 +220,146 @@ var rootCmd = &cobra.Command{
 
 		aiClient, err := ai.NewClient(cfg)
 		if err != nil {
-			log.Fatalf("❌ Failed to initialize AI client: %v", err)
+			return fmt.Errorf("failed to initialize AI client: %w", err)
 		}
 
-		message, err := aiClient.GenerateCommitMessage(diff, context)
+		message, err := aiClient.GenerateCommitMessage(ai.CommitInput{
+			Diff:        diff,
+			FileChanges: git.SummarizeFileChanges(fileChanges),
+			History:     historyContext,
+			Style:       styleSection,
+			Context:     context,
+			Examples:    examples,
+		})
 		if err != nil {
-			log.Fatalf("❌ Error generating commit message: %v", err)
+			return fmt.Errorf("error generating commit message: %w", err)
 		}
 
-		fmt.Println("\n✨ Generated commit message:")
-		fmt.Printf("┌─%s─┐\n", strings.Repeat("─", len(message)))
-		fmt.Printf("│ %s │\n", message)
-		fmt.Printf("└─%s─┘\n", strings.Repeat("─", len(message)))
+		printCommitMessage(message)
 
 		if !skipConfirm {
-			prompt := promptui.Prompt{
-				Label:     "✅ Commit with this message?",
-				IsConfirm: true,
+			if nonInteractive() {
+				fmt.Println("ℹ️  Not committing without confirmation in non-interactive mode, run with --yes to commit.")
+				return nil
 			}
-
-			_, err := prompt.Run()
-			if err != nil {
+			if !confirm("✅ Commit with this message?") {
 				fmt.Println("Commit cancelled.")
-				return
+				return nil
 			}
 		}
 
-		err = gitOps.Commit(message)
+		err = gitOps.StageFiles(untracked)
 		if err != nil {
-			log.Fatalf("❌ Error committing: %v", err)
+			return fmt.Errorf("error staging untracked files: %w", err)
+		}
+
+		switch {
+		case len(paths) > 0:
+			err = gitOps.CommitPaths(message, paths)
+		case stageAll:
+			err = gitOps.StageTracked(nil)
+			if err == nil {
+				err = gitOps.Commit(message)
+			}
+		default:
+			err = gitOps.Commit(message)
+		}
+		if err != nil {
+			return fmt.Errorf("error committing: %w", err)
 		}
 
 		fmt.Println("🎉 Changes committed successfully!")
+		return nil
 	},
 }
 
+// printCommitMessage shows a generated commit message in a box
+func printCommitMessage(message string) {
+	fmt.Println("\n✨ Generated commit message:")
+	fmt.Printf("┌─%s─┐\n", strings.Repeat("─", len(message)))
+	fmt.Printf("│ %s │\n", message)
+	fmt.Printf("└─%s─┘\n", strings.Repeat("─", len(message)))
+}
+
+// patchCommitMessage generates a commit message for the --diff-file patch
+// without committing, using the messages of format-patch series as context
+func patchCommitMessage(cfg *config.Config) error {
+	p, err := readPatch()
+	if err != nil {
+		return err
+	}
+
+	var context []string
+	for i := len(p.Commits) - 1; i >= 0; i-- {
+		commit := p.Commits[i]
+		context = append(context, strings.TrimSpace(fmt.Sprintf("Original commit message: %s\n%s", commit.Subject, commit.Body)))
+	}
+
+	if verbose {
+		fmt.Println("🧠 Generating commit message...")
+	}
+
+	aiClient, err := ai.NewClient(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize AI client: %w", err)
+	}
+
+	message, err := aiClient.GenerateCommitMessage(ai.CommitInput{
+		Diff:        p.Diff,
+		FileChanges: git.SummarizeFileChanges(patch.FileChanges(p.Diff)),
+		Context:     context,
+	})
+	if err != nil {
+		return fmt.Errorf("error generating commit message: %w", err)
+	}
+
+	printCommitMessage(message)
+	return nil
+}
+
+// Execute runs the root command and exits with the code mapped from its error
 func Execute() {
 	err := rootCmd.Execute()
 	if err != nil {
-		os.Exit(1)
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		var usageErr *usageError
+		if errors.As(err, &usageErr) {
+			fmt.Fprintf(os.Stderr, "Run '%s --help' for usage.\n", rootCmd.CommandPath())
+		}
 	}
+	os.Exit(exitCode(err))
 }
 
 func init() {
+	// Errors are reported by Execute, which also maps them to exit codes
+	rootCmd.SilenceErrors = true
+	rootCmd.SilenceUsage = true
+	rootCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return &usageError{err}
+	})
+
+	rootCmd.PersistentFlags().BoolVar(&ciMode, "ci", false, "Never prompt (default: detected from CI=true or a non-terminal stdin)")
 	rootCmd.Flags().BoolVarP(&skipConfirm, "yes", "y", false, "Skip confirmation and commit immediately")
 	rootCmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Show verbose output")
+	rootCmd.Flags().BoolVarP(&stageAll, "all", "a", false, "Stage modified and deleted tracked files before committing")
+	rootCmd.Flags().BoolVar(&includeUntracked, "include-untracked", false, "Add untracked files to the commit")
+	rootCmd.Flags().StringVar(&diffFile, "diff-file", "", "Only suggest a commit message for a patch file, '-' for stdin")
+}
+
+// summarizeUntrackedFiles describes untracked files by name and size,
+// since their content is not part of the diff sent to the AI
+func summarizeUntrackedFiles(files []string) string {
+	summaries := make([]string, len(files))
+	for i, file := range files {
+		content, err := os.ReadFile(file)
+		switch {
+		case err != nil:
+			summaries[i] = file
+		case bytes.IndexByte(content, 0) != -1:
+			summaries[i] = fmt.Sprintf("%s (binary, %d bytes)", file, len(content))
+		default:
+			summaries[i] = fmt.Sprintf("%s (%d lines)", file, bytes.Count(content, []byte("\n")))
+		}
+	}
+	return strings.Join(summaries, ", ")
 }
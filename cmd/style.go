@@ -0,0 +1,168 @@
+/*
+Copyright © 2025 Alexandro Cu alexandro.cuma@gmail.com
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/alexandrocuma/gommit/internal/config"
+	"github.com/alexandrocuma/gommit/internal/git"
+	"github.com/alexandrocuma/gommit/pkg/ai"
+	"github.com/alexandrocuma/gommit/pkg/history"
+	"github.com/alexandrocuma/gommit/pkg/style"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	styleCommitCount int
+	styleUseAI       bool
+)
+
+// styleSummaryTokens caps the commit messages sent to the AI for the style summary
+const styleSummaryTokens = 4000
+
+// styleCmd represents the style command
+var styleCmd = &cobra.Command{
+	Use:   "style",
+	Short: "Manage the commit style profile of this repository",
+	Long: `Learns and shows the commit message style of the current repository.
+
+			The style profile is stored per repository inside the git directory and is
+			automatically added to the commit message prompt, so generated messages
+			match the house style of each repository.
+
+			Examples:
+				gommit style learn              # Analyze the last 200 commits
+				gommit style learn -n 500 --ai  # Analyze more commits and add an AI summary
+				gommit style show               # Display the stored profile`,
+}
+
+// styleLearnCmd represents the style learn command
+var styleLearnCmd = &cobra.Command{
+	Use:   "learn",
+	Short: "Analyze recent commits and store the repository style profile",
+	Long: `Analyzes the last N commits of the current repository and stores a style profile.
+
+			Detected conventions:
+			• Conventional Commits usage, common types and scopes
+			• Emoji and gitmoji usage
+			• Capitalization and trailing periods
+			• Subject length
+			• Ticket reference placement
+
+			Examples:
+				gommit style learn
+				gommit style learn --count 500
+				gommit style learn --ai         # Also ask the AI to summarize the style`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		gitOps := &git.RealGitOperations{}
+		if !gitOps.IsGitRepository() {
+			return git.ErrNotRepository
+		}
+
+		gitDir, err := gitOps.GetCommonGitDir()
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("🔍 Analyzing the last %d commits...\n", styleCommitCount)
+		commits, err := gitOps.GetRecentCommitDetails(styleCommitCount)
+		if err != nil {
+			return fmt.Errorf("failed to get commit history: %w", err)
+		}
+		if len(commits) == 0 {
+			return fmt.Errorf("%w: the repository has no commits to learn from", git.ErrNoChanges)
+		}
+
+		profile := style.Analyze(commits)
+
+		if styleUseAI {
+			cfg, err := config.LoadConfig()
+			if err != nil {
+				return fmt.Errorf("failed to load configuration: %w", err)
+			}
+
+			err = cfg.ValidateAIConfig()
+			if err != nil {
+				return err
+			}
+
+			aiClient, err := ai.NewClient(cfg)
+			if err != nil {
+				return fmt.Errorf("failed to initialize AI client: %w", err)
+			}
+
+			var messages []string
+			budget := history.NewBudget(styleSummaryTokens)
+			for _, commit := range commits {
+				message := commit.Subject
+				if commit.Body != "" {
+					message += "\n\n" + commit.Body
+				}
+				if !budget.Take(message) {
+					break
+				}
+				messages = append(messages, message)
+			}
+
+			fmt.Println("🧠 Summarizing commit style...")
+			profile.Summary, err = aiClient.SummarizeCommitStyle(messages)
+			if err != nil {
+				return fmt.Errorf("error summarizing commit style: %w", err)
+			}
+		}
+
+		path := style.ProfilePath(gitDir)
+		err = style.Save(path, profile)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println()
+		fmt.Println(profile.PromptSection())
+		fmt.Printf("\n✅ Style profile saved to: %s\n", path)
+		return nil
+	},
+}
+
+// styleShowCmd represents the style show command
+var styleShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Display the stored style profile of this repository",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		gitOps := &git.RealGitOperations{}
+		if !gitOps.IsGitRepository() {
+			return git.ErrNotRepository
+		}
+
+		gitDir, err := gitOps.GetCommonGitDir()
+		if err != nil {
+			return err
+		}
+
+		path := style.ProfilePath(gitDir)
+		profile, err := style.Load(path)
+		if err != nil {
+			return err
+		}
+		if profile == nil {
+			fmt.Println("ℹ️  No style profile found. Run 'gommit style learn' first.")
+			return nil
+		}
+
+		fmt.Println(profile.PromptSection())
+		fmt.Printf("\n📄 Profile: %s (updated %s)\n", path, profile.UpdatedAt.Format("2006-01-02 15:04"))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(styleCmd)
+	styleCmd.AddCommand(styleLearnCmd)
+	styleCmd.AddCommand(styleShowCmd)
+
+	styleLearnCmd.Flags().IntVarP(&styleCommitCount, "count", "n", 200, "Number of recent commits to analyze")
+	styleLearnCmd.Flags().BoolVar(&styleUseAI, "ai", false, "Add an AI-written summary of the style to the profile")
+}
@@ -0,0 +1,177 @@
+/*
+Copyright © 2025 Alexandro Cu alexandro.cuma@gmail.com
+*/
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/alexandrocuma/gommit/internal/config"
+	"github.com/alexandrocuma/gommit/internal/git"
+	"github.com/alexandrocuma/gommit/pkg/ai"
+	"github.com/alexandrocuma/gommit/pkg/changelog"
+	"github.com/alexandrocuma/gommit/pkg/semver"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	createVersionTag  bool
+	versionAIClassify bool
+	versionNoAI       bool
+)
+
+// versionCmd represents the version command
+var versionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Suggest the next semantic version from the commits since the latest tag",
+	Long: `Finds the latest semantic version tag, analyzes the commits since then and
+			proposes the next version, explaining why.
+
+			• Breaking changes (type! or BREAKING CHANGE footer) bump the major version
+			• New features (feat) bump the minor version
+			• Fixes and other changes bump the patch version
+
+			With --tag an annotated tag is created, with an AI-written message
+			summarizing the release.
+
+			Examples:
+				gommit version                 # Suggest the next version
+				gommit version --ai-classify   # Let the AI sort commits that are not Conventional Commits
+				gommit version --tag           # Create the annotated tag
+				gommit version --tag --no-ai   # Tag with the plain list of changes`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		gitOps := &git.RealGitOperations{}
+		if !gitOps.IsGitRepository() {
+			return git.ErrNotRepository
+		}
+
+		toRev := toRevision
+		if toRev == "" {
+			toRev = "HEAD"
+		}
+		_, err := gitOps.ResolveRevision(toRev)
+		if err != nil {
+			return &usageError{err}
+		}
+
+		tag, current, err := latestVersionTag(gitOps, toRev)
+		if err != nil {
+			return err
+		}
+		if tag == "" {
+			fmt.Println("📦 No version tag found, starting from 0.0.0")
+		} else {
+			fmt.Printf("📦 Current version: %s\n", tag)
+		}
+
+		commits, err := gitOps.GetReleaseCommits(tag, toRev)
+		if err != nil {
+			return err
+		}
+		if len(commits) == 0 {
+			if tag == "" {
+				return fmt.Errorf("%w since the first commit", git.ErrNoChanges)
+			}
+			return fmt.Errorf("%w since %s", git.ErrNoChanges, tag)
+		}
+
+		var aiClient *ai.Client
+		if !versionNoAI && (createVersionTag || versionAIClassify) {
+			cfg, err := config.LoadConfig()
+			if err != nil {
+				return fmt.Errorf("failed to load configuration: %w", err)
+			}
+
+			err = cfg.ValidateAIConfig()
+			if err != nil {
+				return err
+			}
+
+			aiClient, err = ai.NewClient(cfg)
+			if err != nil {
+				return fmt.Errorf("failed to initialize AI client: %w", err)
+			}
+		}
+
+		var overrides map[string]string
+		if unclassified := changelog.Unclassified(commits); aiClient != nil && versionAIClassify && len(unclassified) > 0 {
+			fmt.Printf("🧠 Classifying %d commits...\n", len(unclassified))
+			overrides, err = aiClient.ClassifyCommits(unclassified, changelog.CategoryKeys())
+			if err != nil {
+				return fmt.Errorf("error classifying commits: %w", err)
+			}
+		}
+
+		groups := changelog.Classify(commits, overrides)
+		bump := changelog.SuggestBump(current, groups)
+		next := current.Bump(bump.Level)
+
+		fmt.Printf("🚀 Next version: %s (%s bump)\n", next, bump.Level)
+		fmt.Println("\n💡 Why:")
+		for _, reason := range bump.Reasons {
+			fmt.Printf("  • %s\n", reason)
+		}
+
+		if !createVersionTag {
+			return nil
+		}
+
+		release := changelog.Release{Version: "Release " + next.String(), Groups: groups}
+		notes, err := changelog.Render(release, changelog.FormatPlain)
+		if err != nil {
+			return err
+		}
+
+		message := notes
+		if aiClient != nil {
+			fmt.Println("\n🧠 Writing tag message...")
+			summary, err := aiClient.SummarizeRelease(notes)
+			if err != nil {
+				return fmt.Errorf("error summarizing release: %w", err)
+			}
+			message = fmt.Sprintf("Release %s\n\n%s", next, summary)
+		}
+
+		err = gitOps.CreateAnnotatedTag(next.String(), toRev, message)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("\n%s\n\n🏷️  Created tag %s\n", strings.TrimSpace(message), next)
+		return nil
+	},
+}
+
+// latestVersionTag returns the highest semantic version tag reachable from rev,
+// or an empty tag and version 0.0.0 when there is none
+func latestVersionTag(gitOps *git.RealGitOperations, rev string) (string, semver.Version, error) {
+	tags, err := gitOps.GetTags(rev)
+	if err != nil {
+		return "", semver.Version{}, err
+	}
+
+	var latestTag string
+	var latest semver.Version
+	for _, tag := range tags {
+		version, ok := semver.Parse(tag)
+		if ok && (latestTag == "" || version.Compare(latest) > 0) {
+			latestTag, latest = tag, version
+		}
+	}
+
+	if latestTag == "" {
+		// Follow the common v-prefixed tag style for the first release
+		return "", semver.Version{Prefix: "v"}, nil
+	}
+	return latestTag, latest, nil
+}
+
+func init() {
+	rootCmd.AddCommand(versionCmd)
+
+	versionCmd.Flags().StringVar(&toRevision, "to", "", "Revision to release (default: HEAD)")
+	versionCmd.Flags().BoolVar(&createVersionTag, "tag", false, "Create an annotated tag for the suggested version")
+	versionCmd.Flags().BoolVar(&versionAIClassify, "ai-classify", false, "Let the AI categorize commits that are not Conventional Commits")
+	versionCmd.Flags().BoolVar(&versionNoAI, "no-ai", false, "Do not use the AI, the tag message lists the changes")
+}
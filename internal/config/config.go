@@ -11,12 +11,20 @@ import (
 type Config struct {
 	AI     		AI     		`yaml:"ai" mapstructure:"ai"`
 	Directory Directory `yaml:"directory" mapstructure:"directory"`
+	History   History   `yaml:"history" mapstructure:"history"`
+	Forge     Forge     `yaml:"forge" mapstructure:"forge"`
+	PR        PR        `yaml:"pr" mapstructure:"pr"`
+	Review    Review    `yaml:"review" mapstructure:"review"`
 }
 
 func DefaultConfig() *Config {
 	return &Config{
 		AI:     	 *DefaultAIConfig(),
 		Directory: *DefaultDirectoryConfig(),
+		History:   *DefaultHistoryConfig(),
+		Forge:     *DefaultForgeConfig(),
+		PR:        *DefaultPRConfig(),
+		Review:    *DefaultReviewConfig(),
 	}
 }
 
@@ -58,7 +66,8 @@ func ConfigExists() bool {
 // LoadConfig loads configuration from file with the following precedence:
 // 1. ./gommit/.gommit.config.yaml
 // 2. ~/.gommit/.gommit.config.yaml
-// If no config file is found, returns default configuration
+// If no config file is found, returns default configuration.
+// GOMMIT_* environment variables override values from either.
 func LoadConfig() (*Config, error) {
 	// Configure Viper to look for .gommit.config.yaml files
 	viper.SetConfigName(".gommit.config")
@@ -74,26 +83,42 @@ func LoadConfig() (*Config, error) {
 	// Set default values
 	viper.SetDefault("ai", DefaultAIConfig())
 	viper.SetDefault("directory", DefaultDirectoryConfig())
+	viper.SetDefault("history", DefaultHistoryConfig())
+	viper.SetDefault("forge", DefaultForgeConfig())
+	viper.SetDefault("pr", DefaultPRConfig())
+	viper.SetDefault("review", DefaultReviewConfig())
 
 	// Attempt to read config file
 	err = viper.ReadInConfig();
 	if err != nil {
 		_, ok := err.(viper.ConfigFileNotFoundError)
-		if ok {
-			// Config file not found, return defaults
-			return DefaultConfig(), nil
+		if !ok {
+			return nil, fmt.Errorf("error reading config file: %w", err)
 		}
-		return nil, fmt.Errorf("error reading config file: %w", err)
+
+		// Config file not found, use defaults and the environment
+		cfg := DefaultConfig()
+		err = applyEnv(cfg)
+		if err != nil {
+			return nil, err
+		}
+		return cfg, nil
 	}
 
-	// Unmarshal config into struct
-	var cfg Config
-	err = viper.Unmarshal(&cfg)
+	// Unmarshal config into the defaults so keys missing from a section keep their default
+	cfg := DefaultConfig()
+	err = viper.Unmarshal(cfg)
 	if err != nil {
 		return nil, fmt.Errorf("error unmarshaling config: %w", err)
 	}
 
-	return &cfg, nil
+	// Environment variables take precedence over the file
+	err = applyEnv(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
 }
 
 // SaveConfig saves configuration to file.
@@ -102,6 +127,10 @@ func LoadConfig() (*Config, error) {
 func SaveConfig(cfg *Config) error {
 	viper.Set("ai", cfg.AI)
 	viper.Set("directory", cfg.Directory)
+	viper.Set("history", cfg.History)
+	viper.Set("forge", cfg.Forge)
+	viper.Set("pr", cfg.PR)
+	viper.Set("review", cfg.Review)
 
 	// Determine where to save
 	configPath := viper.ConfigFileUsed()
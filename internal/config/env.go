@@ -0,0 +1,77 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// EnvPrefix starts the environment variables that override configuration values
+const EnvPrefix = "GOMMIT"
+
+// applyEnv overrides configuration values with environment variables named
+// after their key, e.g. GOMMIT_AI_API_KEY for ai.api_key, so gommit can be
+// configured without a config file
+func applyEnv(cfg *Config) error {
+	return applyEnvFields(reflect.ValueOf(cfg).Elem(), EnvPrefix)
+}
+
+func applyEnvFields(v reflect.Value, prefix string) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		key := t.Field(i).Tag.Get("mapstructure")
+		if key == "" {
+			continue
+		}
+
+		name := prefix + "_" + strings.ToUpper(key)
+		field := v.Field(i)
+		if field.Kind() == reflect.Struct {
+			err := applyEnvFields(field, name)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			continue
+		}
+
+		switch field.Kind() {
+		case reflect.String:
+			field.SetString(value)
+		case reflect.Bool:
+			parsed, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("invalid value %q for %s, expected true or false", value, name)
+			}
+			field.SetBool(parsed)
+		case reflect.Int:
+			parsed, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("invalid value %q for %s, expected an integer", value, name)
+			}
+			field.SetInt(int64(parsed))
+		case reflect.Slice:
+			// Lists are comma separated, e.g. GOMMIT_REVIEW_CATEGORIES=bug,security
+			var items []string
+			for _, item := range strings.Split(value, ",") {
+				if item = strings.TrimSpace(item); item != "" {
+					items = append(items, item)
+				}
+			}
+			field.Set(reflect.ValueOf(items))
+		case reflect.Float64:
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return fmt.Errorf("invalid value %q for %s, expected a number", value, name)
+			}
+			field.SetFloat(parsed)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package config
+
+type Forge struct {
+	Provider string `yaml:"provider" mapstructure:"provider"` // github or gitlab, detected from the remote when empty
+	Remote   string `yaml:"remote" mapstructure:"remote"`
+	GitHub   GitHub `yaml:"github" mapstructure:"github"`
+	GitLab   GitLab `yaml:"gitlab" mapstructure:"gitlab"`
+}
+
+type GitHub struct {
+	Token  string `yaml:"token" mapstructure:"token"`
+	APIURL string `yaml:"api_url" mapstructure:"api_url"`
+}
+
+type GitLab struct {
+	Token              string `yaml:"token" mapstructure:"token"`
+	APIURL             string `yaml:"api_url" mapstructure:"api_url"`
+	Squash             bool   `yaml:"squash" mapstructure:"squash"`
+	RemoveSourceBranch bool   `yaml:"remove_source_branch" mapstructure:"remove_source_branch"`
+}
+
+func DefaultForgeConfig() *Forge {
+	cfg := &Forge{}
+
+	// Forge defaults
+	cfg.Remote = "origin"
+	cfg.GitHub.APIURL = "" // Derived from the remote host, https://api.github.com for github.com
+	cfg.GitLab.APIURL = "" // Derived from the remote host, https://<host>/api/v4
+
+	return cfg
+}
@@ -0,0 +1,22 @@
+package config
+
+type History struct {
+	IncludeAuthors bool `yaml:"include_authors" mapstructure:"include_authors"`
+	FileCommits    int  `yaml:"file_commits" mapstructure:"file_commits"`
+	MaxTokens      int  `yaml:"max_tokens" mapstructure:"max_tokens"`
+	Examples       int  `yaml:"examples" mapstructure:"examples"`
+	ExampleTokens  int  `yaml:"example_tokens" mapstructure:"example_tokens"`
+}
+
+func DefaultHistoryConfig() *History {
+	cfg := &History{}
+
+	// History context defaults
+	cfg.IncludeAuthors = false
+	cfg.FileCommits = 3      // Recent commits per changed file
+	cfg.MaxTokens = 1500     // Approximate token budget for the history context
+	cfg.Examples = 3         // Similar past commits used as few-shot examples
+	cfg.ExampleTokens = 1000 // Approximate token budget for the examples
+
+	return cfg
+}
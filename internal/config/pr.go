@@ -0,0 +1,16 @@
+package config
+
+type PR struct {
+	ConventionalTitle bool `yaml:"conventional_title" mapstructure:"conventional_title"`
+	TitleMaxLength    int  `yaml:"title_max_length" mapstructure:"title_max_length"`
+}
+
+func DefaultPRConfig() *PR {
+	cfg := &PR{}
+
+	// Pull request defaults
+	cfg.ConventionalTitle = false
+	cfg.TitleMaxLength = 72 // Characters, fits the GitHub and GitLab list views
+
+	return cfg
+}
@@ -0,0 +1,20 @@
+package config
+
+type Review struct {
+	RequestChangesOn string   `yaml:"request_changes_on" mapstructure:"request_changes_on"`
+	FailOn           string   `yaml:"fail_on" mapstructure:"fail_on"`
+	MaxFindings      int      `yaml:"max_findings" mapstructure:"max_findings"`
+	Categories       []string `yaml:"categories" mapstructure:"categories"`
+}
+
+func DefaultReviewConfig() *Review {
+	cfg := &Review{}
+
+	// Review defaults
+	cfg.RequestChangesOn = "high" // Posted reviews request changes for findings of this severity or worse
+	cfg.FailOn = ""               // Severity that makes review exit with an error, never when empty
+	cfg.MaxFindings = 0           // Number of findings review tolerates before failing, unlimited when 0
+	cfg.Categories = nil          // Finding categories to report, all when empty
+
+	return cfg
+}
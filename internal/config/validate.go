@@ -1,14 +1,17 @@
 package config
 
 import (
+	"errors"
 	"fmt"
-	"os"
 )
 
-func (c *Config) ValidateAIConfig() {
+// ErrConfigMissing is returned when the configuration required to run a command is not set up
+var ErrConfigMissing = errors.New("configuration missing")
+
+// ValidateAIConfig ensures an AI API key is configured
+func (c *Config) ValidateAIConfig() error {
 	if c.AI.APIKey == "" {
-		fmt.Println("❌ AI API key not configured.")
-		fmt.Println("Please run 'gommit init' to set up your configuration.")
-		os.Exit(1)
+		return fmt.Errorf("%w: AI API key not configured, please run 'gommit init' to set up your configuration or set GOMMIT_AI_API_KEY", ErrConfigMissing)
 	}
+	return nil
 }
@@ -0,0 +1,492 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// lfsPointerPrefix is the first line of every Git LFS pointer file
+const lfsPointerPrefix = "version https://git-lfs.github.com/spec/v1"
+
+// nullSHA is reported by git for blobs that are absent or not hashed yet (working tree)
+const nullSHA = "0000000000000000000000000000000000000000"
+
+// FileChange describes how a single file changed between two trees
+type FileChange struct {
+	Status     byte // A, C, D, M, R or T as reported by git diff --raw
+	Path       string
+	OldPath    string // Source path for renames and copies
+	Similarity int    // Similarity percentage for renames and copies
+	OldMode    string
+	NewMode    string
+	Added      int
+	Deleted    int
+	Binary     bool
+	OldSize    int64 // Blob sizes, only filled in for binary files and LFS pointers
+	NewSize    int64
+	LFS        bool
+}
+
+// GetStagedFileChanges returns the per-file changes of the staged diff
+func (g *RealGitOperations) GetStagedFileChanges() ([]FileChange, error) {
+	return g.getFileChanges([]string{"--staged"}, true)
+}
+
+// GetWorkingTreeFileChanges returns the per-file changes of tracked files in
+// the working tree against HEAD, optionally limited to the given pathspecs
+func (g *RealGitOperations) GetWorkingTreeFileChanges(paths []string) ([]FileChange, error) {
+	return g.getFileChanges(withPathspecs([]string{"HEAD"}, paths), false)
+}
+
+// GetFileChangesBetweenBranches returns the per-file changes between two revisions
+func (g *RealGitOperations) GetFileChangesBetweenBranches(baseBranch, compareBranch string) ([]FileChange, error) {
+	return g.getFileChanges([]string{fmt.Sprintf("%s..%s", baseBranch, compareBranch)}, true)
+}
+
+// getFileChanges combines --raw (status, modes, blobs) and --numstat (line counts)
+// output for the given diff arguments. When newSideInRepo is false the new side
+// of the diff is read from the working tree instead of the object database.
+func (g *RealGitOperations) getFileChanges(diffArgs []string, newSideInRepo bool) ([]FileChange, error) {
+	rawArgs := append([]string{"diff", "--raw", "-z", "--no-abbrev", "--find-renames", "--find-copies"}, diffArgs...)
+	rawOutput, err := exec.Command("git", rawArgs...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get file changes: %w", err)
+	}
+
+	numstatArgs := append([]string{"diff", "--numstat", "-z", "--find-renames", "--find-copies"}, diffArgs...)
+	numstatOutput, err := exec.Command("git", numstatArgs...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get file change stats: %w", err)
+	}
+
+	changes, blobs := parseRawDiff(rawOutput)
+	stats := parseNumstat(numstatOutput)
+
+	// Working tree files are reported relative to the top level
+	topLevel := ""
+	if !newSideInRepo {
+		topLevel, err = g.GetTopLevel()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var shas []string
+	for _, blob := range blobs {
+		shas = append(shas, blob[0], blob[1])
+	}
+	blobHeads := readBlobHeads(shas)
+
+	for i := range changes {
+		change := &changes[i]
+		stat, ok := stats[change.Path]
+		if ok {
+			change.Added = stat.added
+			change.Deleted = stat.deleted
+			change.Binary = stat.binary
+		}
+
+		oldHead := blobHeads[blobs[i][0]]
+		newHead, ok := blobHeads[blobs[i][1]]
+		if !ok && !newSideInRepo {
+			newHead = readFileHead(filepath.Join(topLevel, change.Path))
+		}
+
+		oldPointer, oldIsLFS := parseLFSPointer(oldHead.content)
+		newPointer, newIsLFS := parseLFSPointer(newHead.content)
+		switch {
+		case oldIsLFS || newIsLFS:
+			change.LFS = true
+			change.OldSize = oldPointer
+			change.NewSize = newPointer
+		case change.Binary:
+			change.OldSize = oldHead.size
+			change.NewSize = newHead.size
+		}
+	}
+
+	return changes, nil
+}
+
+// parseRawDiff parses `git diff --raw -z` output and returns the changes
+// along with the old and new blob SHAs of each change
+func parseRawDiff(output []byte) ([]FileChange, [][2]string) {
+	var changes []FileChange
+	var blobs [][2]string
+
+	fields := strings.Split(string(output), "\x00")
+	for i := 0; i < len(fields); i++ {
+		header := fields[i]
+		if !strings.HasPrefix(header, ":") {
+			continue
+		}
+
+		// :<old mode> <new mode> <old sha> <new sha> <status><score>
+		parts := strings.Fields(header[1:])
+		if len(parts) < 5 || i+1 >= len(fields) {
+			continue
+		}
+
+		change := FileChange{
+			Status:  parts[4][0],
+			OldMode: parts[0],
+			NewMode: parts[1],
+		}
+		if len(parts[4]) > 1 {
+			change.Similarity, _ = strconv.Atoi(parts[4][1:])
+		}
+
+		if change.Status == 'R' || change.Status == 'C' {
+			if i+2 >= len(fields) {
+				break
+			}
+			change.OldPath = fields[i+1]
+			change.Path = fields[i+2]
+			i += 2
+		} else {
+			change.Path = fields[i+1]
+			i++
+		}
+
+		changes = append(changes, change)
+		blobs = append(blobs, [2]string{parts[2], parts[3]})
+	}
+
+	return changes, blobs
+}
+
+type numstat struct {
+	added   int
+	deleted int
+	binary  bool
+}
+
+// parseNumstat parses `git diff --numstat -z` output keyed by the new path
+func parseNumstat(output []byte) map[string]numstat {
+	stats := make(map[string]numstat)
+
+	fields := strings.Split(string(output), "\x00")
+	for i := 0; i < len(fields); i++ {
+		parts := strings.SplitN(fields[i], "\t", 3)
+		if len(parts) != 3 {
+			continue
+		}
+
+		stat := numstat{binary: parts[0] == "-" && parts[1] == "-"}
+		stat.added, _ = strconv.Atoi(parts[0])
+		stat.deleted, _ = strconv.Atoi(parts[1])
+
+		// Renames and copies have an empty path followed by the old and new paths
+		filePath := parts[2]
+		if filePath == "" && i+2 < len(fields) {
+			filePath = fields[i+2]
+			i += 2
+		}
+		stats[filePath] = stat
+	}
+
+	return stats
+}
+
+// lfsPointerMaxSize is the largest blob read in full, only small blobs can be
+// LFS pointers and large binaries are never loaded
+const lfsPointerMaxSize = 1024
+
+// blobHead is the size of a blob and its content when it is small enough to
+// be an LFS pointer
+type blobHead struct {
+	content []byte
+	size    int64
+}
+
+// readBlobHeads returns the heads of the given blobs keyed by SHA, blobs that
+// are absent or not hashed yet are left out. Sizes are read with a single
+// git cat-file --batch-check and small blobs with a single git cat-file --batch.
+func readBlobHeads(shas []string) map[string]blobHead {
+	heads := make(map[string]blobHead)
+
+	var hashed []string
+	for _, sha := range shas {
+		if sha != nullSHA && sha != "" && !slices.Contains(hashed, sha) {
+			hashed = append(hashed, sha)
+		}
+	}
+	if len(hashed) == 0 {
+		return heads
+	}
+
+	output, err := catFileBatch("--batch-check", hashed)
+	if err != nil {
+		return heads
+	}
+	var small []string
+	reader := bufio.NewReader(bytes.NewReader(output))
+	for {
+		sha, size, ok := readBatchHeader(reader)
+		if !ok {
+			break
+		}
+		if size < 0 {
+			continue
+		}
+		heads[sha] = blobHead{size: size}
+		if size <= lfsPointerMaxSize {
+			small = append(small, sha)
+		}
+	}
+	if len(small) == 0 {
+		return heads
+	}
+
+	output, err = catFileBatch("--batch", small)
+	if err != nil {
+		return heads
+	}
+	reader = bufio.NewReader(bytes.NewReader(output))
+	for {
+		sha, size, ok := readBatchHeader(reader)
+		if !ok {
+			break
+		}
+		if size < 0 {
+			continue
+		}
+		// Each object is followed by a newline
+		content := make([]byte, size+1)
+		if _, err := io.ReadFull(reader, content); err != nil {
+			break
+		}
+		heads[sha] = blobHead{content: content[:size], size: size}
+	}
+
+	return heads
+}
+
+// catFileBatch runs git cat-file in the given batch mode over the objects
+func catFileBatch(mode string, shas []string) ([]byte, error) {
+	cmd := exec.Command("git", "cat-file", mode)
+	cmd.Stdin = strings.NewReader(strings.Join(shas, "\n") + "\n")
+	return cmd.Output()
+}
+
+// readBatchHeader reads a "<sha> <type> <size>" line of git cat-file batch
+// output, the size is -1 for missing objects
+func readBatchHeader(reader *bufio.Reader) (string, int64, bool) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return "", 0, false
+	}
+	parts := strings.Fields(line)
+	if len(parts) == 0 {
+		return "", 0, false
+	}
+	if len(parts) != 3 {
+		return parts[0], -1, true
+	}
+	size, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return parts[0], -1, true
+	}
+	return parts[0], size, true
+}
+
+// readFileHead returns the first bytes and the total size of a working tree file
+func readFileHead(filePath string) blobHead {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return blobHead{}
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return blobHead{}
+	}
+
+	// Only the head is needed to detect LFS pointers, avoid loading large binaries
+	head := make([]byte, lfsPointerMaxSize)
+	n, _ := io.ReadFull(file, head)
+	return blobHead{content: head[:n], size: info.Size()}
+}
+
+// parseLFSPointer reports whether content is a Git LFS pointer and returns
+// the size of the object it points to
+func parseLFSPointer(content []byte) (int64, bool) {
+	if !bytes.HasPrefix(content, []byte(lfsPointerPrefix)) {
+		return 0, false
+	}
+
+	for _, line := range strings.Split(string(content), "\n") {
+		value, ok := strings.CutPrefix(line, "size ")
+		if ok {
+			size, _ := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+			return size, true
+		}
+	}
+	return 0, true
+}
+
+// SummarizeFileChanges renders the notable file changes (renames, copies, mode
+// and type changes, binary files and LFS objects, additions and deletions) as a
+// compact list for AI prompts. Plain content modifications are left to the diff.
+func SummarizeFileChanges(changes []FileChange) string {
+	var lines []string
+
+	// Pure renames that share the same path transformation are collapsed into one line
+	type move struct{ from, to string }
+	moves := make(map[move][]FileChange)
+	var moveOrder []move
+
+	var added, deleted []string
+	for _, change := range changes {
+		switch {
+		case change.Status == 'R' && change.Similarity == 100:
+			from, to := renamePrefixes(change.OldPath, change.Path)
+			key := move{from, to}
+			if _, ok := moves[key]; !ok {
+				moveOrder = append(moveOrder, key)
+			}
+			moves[key] = append(moves[key], change)
+			continue
+		case change.Status == 'R':
+			lines = append(lines, fmt.Sprintf("Renamed: %s → %s (%d%% similar, +%d -%d)", change.OldPath, change.Path, change.Similarity, change.Added, change.Deleted))
+		case change.Status == 'C':
+			lines = append(lines, fmt.Sprintf("Copied: %s → %s (%d%% similar)", change.OldPath, change.Path, change.Similarity))
+		case change.Status == 'T':
+			lines = append(lines, fmt.Sprintf("Type changed: %s (%s → %s)", change.Path, describeMode(change.OldMode), describeMode(change.NewMode)))
+		case change.Status == 'A' && !change.Binary && !change.LFS:
+			added = append(added, fmt.Sprintf("%s (+%d)", change.Path, change.Added))
+		case change.Status == 'D' && !change.Binary && !change.LFS:
+			deleted = append(deleted, fmt.Sprintf("%s (-%d)", change.Path, change.Deleted))
+		}
+
+		if change.Status == 'M' && change.OldMode != change.NewMode {
+			lines = append(lines, fmt.Sprintf("Mode changed: %s (%s → %s)", change.Path, change.OldMode, change.NewMode))
+		}
+
+		switch {
+		case change.LFS:
+			lines = append(lines, fmt.Sprintf("Git LFS object: %s %s", change.Path, describeSizeChange(change)))
+		case change.Binary:
+			lines = append(lines, fmt.Sprintf("Binary file: %s %s", change.Path, describeSizeChange(change)))
+		}
+	}
+
+	for _, key := range moveOrder {
+		files := moves[key]
+		if len(files) == 1 {
+			lines = append(lines, fmt.Sprintf("Moved: %s → %s", files[0].OldPath, files[0].Path))
+			continue
+		}
+		from := key.from
+		if from == "" {
+			from = "./"
+		}
+		lines = append(lines, fmt.Sprintf("Moved: %d files from %s into %s", len(files), from, key.to))
+	}
+
+	if len(added) > 0 {
+		lines = append(lines, "Added: "+strings.Join(added, ", "))
+	}
+	if len(deleted) > 0 {
+		lines = append(lines, "Deleted: "+strings.Join(deleted, ", "))
+	}
+
+	sort.SliceStable(lines, func(i, j int) bool {
+		return summaryOrder(lines[i]) < summaryOrder(lines[j])
+	})
+	return strings.Join(lines, "\n")
+}
+
+// renamePrefixes strips the longest common trailing path of a rename and
+// returns the differing directory prefixes, e.g. pkg/a/x.go → internal/a/x.go
+// yields "pkg/" and "internal/"
+func renamePrefixes(oldPath, newPath string) (string, string) {
+	oldParts := strings.Split(oldPath, "/")
+	newParts := strings.Split(newPath, "/")
+
+	common := 0
+	for common < len(oldParts) && common < len(newParts) &&
+		oldParts[len(oldParts)-1-common] == newParts[len(newParts)-1-common] {
+		common++
+	}
+
+	from := path.Join(oldParts[:len(oldParts)-common]...)
+	to := path.Join(newParts[:len(newParts)-common]...)
+	if from != "" {
+		from += "/"
+	}
+	if to != "" {
+		to += "/"
+	}
+	return from, to
+}
+
+// summaryOrder keeps structural changes first in the summary
+func summaryOrder(line string) int {
+	for i, prefix := range []string{"Moved", "Renamed", "Copied", "Added", "Deleted", "Mode", "Type", "Binary", "Git LFS"} {
+		if strings.HasPrefix(line, prefix) {
+			return i
+		}
+	}
+	return math.MaxInt
+}
+
+// describeMode turns a git file mode into a readable file type
+func describeMode(mode string) string {
+	switch mode {
+	case "120000":
+		return "symlink"
+	case "160000":
+		return "submodule"
+	case "100755":
+		return "executable file"
+	case "000000":
+		return "none"
+	default:
+		return "regular file"
+	}
+}
+
+// describeSizeChange renders the size delta of binary files and LFS objects
+func describeSizeChange(change FileChange) string {
+	switch change.Status {
+	case 'A':
+		return fmt.Sprintf("added (%s)", formatSize(change.NewSize))
+	case 'D':
+		return fmt.Sprintf("deleted (%s)", formatSize(change.OldSize))
+	}
+
+	delta := change.NewSize - change.OldSize
+	sign := "+"
+	if delta < 0 {
+		sign = "-"
+		delta = -delta
+	}
+	return fmt.Sprintf("%s → %s (%s%s)", formatSize(change.OldSize), formatSize(change.NewSize), sign, formatSize(delta))
+}
+
+// formatSize renders a byte count with a binary unit suffix
+func formatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,11 @@
+package git
+
+import "errors"
+
+var (
+	// ErrNotRepository is returned when the working directory is not inside a git repository
+	ErrNotRepository = errors.New("not a git repository")
+
+	// ErrNoChanges is returned when there is nothing to commit, describe or review
+	ErrNoChanges = errors.New("no changes found")
+)
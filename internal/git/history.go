@@ -0,0 +1,154 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// Commit holds the metadata and full message of a single commit
+type Commit struct {
+	Hash    string
+	Author  string
+	Date    time.Time
+	Subject string
+	Body    string
+	Files   []string // Changed files, only filled in by GetCommitsTouching
+}
+
+// ShortHash returns the abbreviated commit hash used for display
+func (c Commit) ShortHash() string {
+	if len(c.Hash) > 7 {
+		return c.Hash[:7]
+	}
+	return c.Hash
+}
+
+// logFormat separates fields with a unit separator and commits with a record separator
+const logFormat = "--format=%H%x1f%an%x1f%aI%x1f%s%x1f%b%x1e"
+
+// GetCommitDetailsBetweenBranches returns the full commits between two revisions, newest first
+func (g *RealGitOperations) GetCommitDetailsBetweenBranches(baseBranch, compareBranch string) ([]Commit, error) {
+	commits, err := g.log(fmt.Sprintf("%s..%s", baseBranch, compareBranch))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commits between branches: %w", err)
+	}
+	return commits, nil
+}
+
+// GetReleaseCommits returns the commits between two revisions without merge
+// commits, newest first. An empty from returns all commits reachable from to.
+func (g *RealGitOperations) GetReleaseCommits(from, to string) ([]Commit, error) {
+	revRange := to
+	if from != "" {
+		revRange = fmt.Sprintf("%s..%s", from, to)
+	}
+	commits, err := g.log("--no-merges", revRange)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commits between %s and %s: %w", from, to, err)
+	}
+	return commits, nil
+}
+
+// GetFileHistory returns the last commits reachable from rev that touched the
+// given path, relative to the repository root
+func (g *RealGitOperations) GetFileHistory(rev, path string, count int) ([]Commit, error) {
+	commits, err := g.log(fmt.Sprintf("-%d", count), "--follow", rev, "--", topPathspecs([]string{path})[0])
+	if err != nil {
+		return nil, fmt.Errorf("failed to get history of %s: %w", path, err)
+	}
+	return commits, nil
+}
+
+// log runs git log with the given arguments and parses the commits
+func (g *RealGitOperations) log(args ...string) ([]Commit, error) {
+	cmdArgs := append([]string{"log", "--no-decorate", logFormat}, args...)
+	output, err := exec.Command("git", cmdArgs...).Output()
+	if err != nil {
+		return nil, err
+	}
+	return parseLog(string(output)), nil
+}
+
+// parseLog parses git log output produced with logFormat
+func parseLog(output string) []Commit {
+	var commits []Commit
+	for _, record := range strings.Split(output, "\x1e") {
+		commit, ok := parseCommit(record)
+		if ok {
+			commits = append(commits, commit)
+		}
+	}
+	return commits
+}
+
+// parseCommit parses the fields of a single commit formatted with logFormat
+func parseCommit(record string) (Commit, bool) {
+	record = strings.TrimLeft(record, "\n")
+	fields := strings.SplitN(record, "\x1f", 5)
+	if len(fields) != 5 {
+		return Commit{}, false
+	}
+
+	date, _ := time.Parse(time.RFC3339, fields[2])
+	return Commit{
+		Hash:    fields[0],
+		Author:  fields[1],
+		Date:    date,
+		Subject: fields[3],
+		Body:    strings.TrimSpace(fields[4]),
+	}, true
+}
+
+// GetRecentCommitDetails returns the last non-merge commits reachable from HEAD
+func (g *RealGitOperations) GetRecentCommitDetails(count int) ([]Commit, error) {
+	commits, err := g.log(fmt.Sprintf("-%d", count), "--no-merges")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recent commits: %w", err)
+	}
+	return commits, nil
+}
+
+// GetCommitsTouching returns the last non-merge commits reachable from HEAD that
+// touched any of the given paths, relative to the repository root, including
+// the files each commit changed
+func (g *RealGitOperations) GetCommitsTouching(paths []string, count int) ([]Commit, error) {
+	// Records start with a record separator and the message ends with a group
+	// separator, the --name-only file list follows until the next record
+	args := []string{"log", "--no-decorate", "--no-merges", "--name-only", "--format=%x1e%H%x1f%an%x1f%aI%x1f%s%x1f%b%x1d", fmt.Sprintf("-%d", count)}
+	output, err := exec.Command("git", withPathspecs(args, topPathspecs(paths))...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commits touching paths: %w", err)
+	}
+
+	var commits []Commit
+	for _, record := range strings.Split(string(output), "\x1e") {
+		message, files, found := strings.Cut(record, "\x1d")
+		if !found {
+			continue
+		}
+
+		commit, ok := parseCommit(message)
+		if !ok {
+			continue
+		}
+		for _, file := range strings.Split(files, "\n") {
+			file = strings.TrimSpace(file)
+			if file != "" {
+				commit.Files = append(commit.Files, file)
+			}
+		}
+		commits = append(commits, commit)
+	}
+	return commits, nil
+}
+
+// GetCommitStat returns the diffstat of a single commit
+func (g *RealGitOperations) GetCommitStat(hash string) (string, error) {
+	output, err := exec.Command("git", "show", "--stat=100", "--format=", hash).Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get stats of commit %s: %w", hash, err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
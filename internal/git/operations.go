@@ -2,7 +2,6 @@ package git
 
 import (
 	"fmt"
-	"os"
 	"os/exec"
 	"strings"
 )
@@ -18,6 +17,41 @@ type GitOperations interface {
 	GetCommitsBetweenBranches(baseBranch, compareBranch string) ([]string, error)
 	GetDiffStatsBetweenBranches(baseBranch, compareBranch string) (string, error)
 	BranchExists(branch string) bool
+	// Working tree support
+	GetWorkingTreeDiff(paths []string) (string, error)
+	GetUntrackedFiles(paths []string) ([]string, error)
+	StageTracked(paths []string) error
+	StageFiles(files []string) error
+	CommitPaths(message string, paths []string) error
+	// Revision support
+	ResolveRevision(rev string) (string, error)
+	IsDetachedHead() bool
+	GetHeadRevision() (string, error)
+	ShortRevision(rev string) (string, error)
+	RemoteBranchExists(remote, branch string) bool
+	// File change summaries
+	GetStagedFileChanges() ([]FileChange, error)
+	GetWorkingTreeFileChanges(paths []string) ([]FileChange, error)
+	GetFileChangesBetweenBranches(baseBranch, compareBranch string) ([]FileChange, error)
+	// History context
+	GetCommitDetailsBetweenBranches(baseBranch, compareBranch string) ([]Commit, error)
+	GetFileHistory(rev, path string, count int) ([]Commit, error)
+	GetRecentCommitDetails(count int) ([]Commit, error)
+	GetCommonGitDir() (string, error)
+	GetTopLevel() (string, error)
+	GetCommitsTouching(paths []string, count int) ([]Commit, error)
+	GetCommitStat(hash string) (string, error)
+	GetUserName() string
+	GetReleaseCommits(from, to string) ([]Commit, error)
+	// Tag support
+	GetLatestTag(rev string) (string, error)
+	IsTag(rev string) bool
+	GetTags(rev string) ([]string, error)
+	CreateAnnotatedTag(name, rev, message string) error
+	// Remote support
+	GetRemoteURL(remote string) (string, error)
+	IsBranchPushed(remote, branch string) bool
+	PushBranch(remote, branch string) error
 }
 
 type RealGitOperations struct{}
@@ -33,6 +67,13 @@ func (g *RealGitOperations) GetDefaultBaseBranch() string {
 		}
 	}
 
+	// CI checkouts often only have the remote-tracking branches
+	for _, branch := range possibleBranches {
+		if g.RemoteBranchExists("origin", branch) {
+			return "origin/" + branch
+		}
+	}
+
 	// Fallback to main
 	return "main"
 }
@@ -43,21 +84,14 @@ func (g *RealGitOperations) IsGitRepository() bool {
 }
 
 func (g *RealGitOperations) GetStagedDiff() (string, error) {
-	cmd := exec.Command("git", "diff", "--staged")
+	cmd := exec.Command("git", "diff", "--staged", "--find-renames", "--find-copies")
 
 	output, err := cmd.Output()
 	if err != nil {
 		return "", fmt.Errorf("failed to get staged diff: %w", err)
 	}
 
-	diff := string(output)
-	if diff == "" {
-		fmt.Println("❌ No staged changes found.")
-		fmt.Println("   Please stage your changes first: git add <files>")
-		os.Exit(1)
-	}
-
-	return diff, nil
+	return string(output), nil
 }
 
 func (g *RealGitOperations) GetCurrentBranch() (string, error) {
@@ -69,6 +103,16 @@ func (g *RealGitOperations) GetCurrentBranch() (string, error) {
 	return strings.TrimSpace(string(output)), nil
 }
 
+// GetUserName returns the configured git user.name, empty when unset
+func (g *RealGitOperations) GetUserName() string {
+	cmd := exec.Command("git", "config", "user.name")
+	output, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
 func (g *RealGitOperations) GetRecentCommits(count int) ([]string, error) {
 	cmd := exec.Command("git", "log", fmt.Sprintf("-%d", count), "--oneline", "--no-decorate")
 	output, err := cmd.Output()
@@ -92,7 +136,7 @@ func (g *RealGitOperations) Commit(message string) error {
 
 // NEW: Get diff between two branches
 func (g *RealGitOperations) GetDiffBetweenBranches(baseBranch, compareBranch string) (string, error) {
-	cmd := exec.Command("git", "diff", fmt.Sprintf("%s..%s", baseBranch, compareBranch))
+	cmd := exec.Command("git", "diff", "--find-renames", "--find-copies", fmt.Sprintf("%s..%s", baseBranch, compareBranch))
 
 	output, err := cmd.Output()
 	if err != nil {
@@ -101,9 +145,7 @@ func (g *RealGitOperations) GetDiffBetweenBranches(baseBranch, compareBranch str
 
 	diff := string(output)
 	if diff == "" {
-		fmt.Println("❌ No staged changes found.")
-		fmt.Println("   Please stage your changes first: git add <files>")
-		os.Exit(1)
+		return "", fmt.Errorf("%w between %s and %s", ErrNoChanges, baseBranch, compareBranch)
 	}
 
 	return diff, nil
@@ -123,7 +165,7 @@ func (g *RealGitOperations) GetCommitsBetweenBranches(baseBranch, compareBranch
 
 // NEW: Get diff statistics between branches
 func (g *RealGitOperations) GetDiffStatsBetweenBranches(baseBranch, compareBranch string) (string, error) {
-	cmd := exec.Command("git", "diff", "--stat", fmt.Sprintf("%s..%s", baseBranch, compareBranch))
+	cmd := exec.Command("git", "diff", "--stat", "--summary", "--find-renames", "--find-copies", fmt.Sprintf("%s..%s", baseBranch, compareBranch))
 	output, err := cmd.Output()
 	if err != nil {
 		return "", fmt.Errorf("failed to get diff stats between branches: %w", err)
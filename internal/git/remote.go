@@ -0,0 +1,42 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// GetRemoteURL returns the fetch URL of a remote
+func (g *RealGitOperations) GetRemoteURL(remote string) (string, error) {
+	cmd := exec.Command("git", "remote", "get-url", remote)
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get URL of remote %q: %w", remote, err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// IsBranchPushed reports whether the remote-tracking branch exists and contains
+// every commit of the local branch
+func (g *RealGitOperations) IsBranchPushed(remote, branch string) bool {
+	if !g.RemoteBranchExists(remote, branch) {
+		return false
+	}
+
+	cmd := exec.Command("git", "rev-list", "--count", fmt.Sprintf("%s/%s..%s", remote, branch, branch))
+	output, err := cmd.Output()
+	if err != nil {
+		return false
+	}
+	return strings.TrimSpace(string(output)) == "0"
+}
+
+// PushBranch pushes a branch and sets its upstream
+func (g *RealGitOperations) PushBranch(remote, branch string) error {
+	cmd := exec.Command("git", "push", "--set-upstream", remote, branch)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to push %s to %s: %w\n%s", branch, remote, err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
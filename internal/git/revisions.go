@@ -0,0 +1,83 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// ResolveRevision resolves any revision (branch, tag, SHA, HEAD~5, ...)
+// to the full SHA of the commit it points to
+func (g *RealGitOperations) ResolveRevision(rev string) (string, error) {
+	cmd := exec.Command("git", "rev-parse", "--verify", "--quiet", rev+"^{commit}")
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("unknown revision %q", rev)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// IsDetachedHead reports whether HEAD points directly at a commit instead of a branch
+func (g *RealGitOperations) IsDetachedHead() bool {
+	cmd := exec.Command("git", "symbolic-ref", "--quiet", "HEAD")
+	return cmd.Run() != nil
+}
+
+// GetHeadRevision returns the name of the current branch, or "HEAD" when
+// the repository is in detached HEAD state (CI checkouts, bisect, ...)
+func (g *RealGitOperations) GetHeadRevision() (string, error) {
+	if g.IsDetachedHead() {
+		return "HEAD", nil
+	}
+	return g.GetCurrentBranch()
+}
+
+// ShortRevision returns the abbreviated SHA of a revision, used for display
+func (g *RealGitOperations) ShortRevision(rev string) (string, error) {
+	cmd := exec.Command("git", "rev-parse", "--short", rev)
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to abbreviate revision %q: %w", rev, err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// RemoteBranchExists checks if a remote-tracking branch exists, e.g. origin/main
+func (g *RealGitOperations) RemoteBranchExists(remote, branch string) bool {
+	cmd := exec.Command("git", "show-ref", "--verify", "--quiet", fmt.Sprintf("refs/remotes/%s/%s", remote, branch))
+	return cmd.Run() == nil
+}
+
+// GetCommonGitDir returns the absolute path of the git directory shared by
+// all worktrees of the repository, so per-repository files are stored once
+func (g *RealGitOperations) GetCommonGitDir() (string, error) {
+	cmd := exec.Command("git", "rev-parse", "--git-common-dir")
+	output, err := cmd.Output()
+	if err != nil {
+		return "", ErrNotRepository
+	}
+	// The path is relative to the current directory unless it is outside of it
+	return filepath.Abs(strings.TrimSpace(string(output)))
+}
+
+// GetTopLevel returns the absolute path of the working tree root
+func (g *RealGitOperations) GetTopLevel() (string, error) {
+	cmd := exec.Command("git", "rev-parse", "--show-toplevel")
+	output, err := cmd.Output()
+	if err != nil {
+		return "", ErrNotRepository
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// GetMergeBase returns the best common ancestor of two revisions, the point
+// a pull request diff is computed from
+func (g *RealGitOperations) GetMergeBase(a, b string) (string, error) {
+	cmd := exec.Command("git", "merge-base", a, b)
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("no common ancestor of %s and %s", a, b)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
@@ -0,0 +1,44 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// GetLatestTag returns the most recent tag reachable from rev
+func (g *RealGitOperations) GetLatestTag(rev string) (string, error) {
+	cmd := exec.Command("git", "describe", "--tags", "--abbrev=0", rev)
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("no tag found before %s, pass --from", rev)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// IsTag reports whether rev names an existing tag
+func (g *RealGitOperations) IsTag(rev string) bool {
+	cmd := exec.Command("git", "show-ref", "--verify", "--quiet", "refs/tags/"+rev)
+	return cmd.Run() == nil
+}
+
+// GetTags returns the tags reachable from rev
+func (g *RealGitOperations) GetTags(rev string) ([]string, error) {
+	cmd := exec.Command("git", "tag", "--list", "--merged", rev)
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+	return strings.Fields(string(output)), nil
+}
+
+// CreateAnnotatedTag creates an annotated tag on rev with the given message
+func (g *RealGitOperations) CreateAnnotatedTag(name, rev, message string) error {
+	cmd := exec.Command("git", "tag", "--annotate", name, "--file", "-", rev)
+	cmd.Stdin = strings.NewReader(message)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to create tag %s: %s", name, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
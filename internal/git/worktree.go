@@ -0,0 +1,98 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// GetWorkingTreeDiff returns the diff of tracked files in the working tree
+// against HEAD, optionally limited to the given pathspecs
+func (g *RealGitOperations) GetWorkingTreeDiff(paths []string) (string, error) {
+	args := withPathspecs([]string{"diff", "--find-renames", "--find-copies", "HEAD"}, paths)
+	cmd := exec.Command("git", args...)
+
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get working tree diff: %w", err)
+	}
+	return string(output), nil
+}
+
+// GetUntrackedFiles lists untracked files that are not ignored,
+// optionally limited to the given pathspecs
+func (g *RealGitOperations) GetUntrackedFiles(paths []string) ([]string, error) {
+	args := withPathspecs([]string{"ls-files", "--others", "--exclude-standard", "-z"}, paths)
+	cmd := exec.Command("git", args...)
+
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list untracked files: %w", err)
+	}
+
+	var files []string
+	for _, file := range strings.Split(string(output), "\x00") {
+		if file != "" {
+			files = append(files, file)
+		}
+	}
+	return files, nil
+}
+
+// StageTracked stages modifications and deletions of tracked files,
+// optionally limited to the given pathspecs
+func (g *RealGitOperations) StageTracked(paths []string) error {
+	args := withPathspecs([]string{"add", "--update"}, paths)
+	cmd := exec.Command("git", args...)
+	err := cmd.Run()
+	if err != nil {
+		return fmt.Errorf("failed to stage tracked changes: %w", err)
+	}
+	return nil
+}
+
+// StageFiles stages the given files, including untracked ones
+func (g *RealGitOperations) StageFiles(files []string) error {
+	if len(files) == 0 {
+		return nil
+	}
+
+	args := withPathspecs([]string{"add"}, files)
+	cmd := exec.Command("git", args...)
+	err := cmd.Run()
+	if err != nil {
+		return fmt.Errorf("failed to stage files: %w", err)
+	}
+	return nil
+}
+
+// CommitPaths commits the working tree content of the given paths only,
+// leaving any other staged changes untouched
+func (g *RealGitOperations) CommitPaths(message string, paths []string) error {
+	args := withPathspecs([]string{"commit", "-m", message}, paths)
+	cmd := exec.Command("git", args...)
+	err := cmd.Run()
+	if err != nil {
+		return fmt.Errorf("failed to commit paths: %w", err)
+	}
+	return nil
+}
+
+// withPathspecs appends pathspecs to a git command, separated by "--"
+func withPathspecs(args []string, paths []string) []string {
+	if len(paths) == 0 {
+		return args
+	}
+	args = append(args, "--")
+	return append(args, paths...)
+}
+
+// topPathspecs marks paths relative to the repository root, as git diff and
+// git log report them, so git does not read them from the current directory
+func topPathspecs(paths []string) []string {
+	pathspecs := make([]string, len(paths))
+	for i, path := range paths {
+		pathspecs[i] = ":(top)" + path
+	}
+	return pathspecs
+}
@@ -3,11 +3,18 @@ package ai
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/alexandrocuma/gommit/internal/config"
+	"github.com/alexandrocuma/gommit/internal/git"
 	"github.com/alexandrocuma/gommit/pkg/ai/providers"
+	"github.com/alexandrocuma/gommit/pkg/conventional"
 	"github.com/alexandrocuma/gommit/pkg/directory"
+	"github.com/alexandrocuma/gommit/pkg/markdown"
+	"github.com/alexandrocuma/gommit/pkg/review"
+	"github.com/alexandrocuma/gommit/pkg/ticket"
 )
 
 // Client implements the AI operations using the configured provider
@@ -15,6 +22,7 @@ type Client struct {
 	provider providers.Provider
 	cfg      *config.AI
 	dirs config.Directory
+	usage    Usage
 }
 
 // NewClient creates a new AI client
@@ -32,28 +40,42 @@ func NewClient(cfg *config.Config) (*Client, error) {
 }
 
 // GenerateCommitMessage creates a commit message using the configured AI provider
-func (c *Client) GenerateCommitMessage(diff string, data []string) (string, error) {
-	prompt, err := directory.LoadTemplate(c.dirs.Prompts, "commit.md")
-	if err != nil {
-		return "", err
-	}
-	if prompt == "" {
-		return "", fmt.Errorf("prompt is missing, check your 'pr description generator' prompt file (commit.md)")
-	}
+func (c *Client) GenerateCommitMessage(input CommitInput) (string, error) {
+	prompt := c.loadPrompt("commit.md", DefaultCommitPrompt)
+
+	content := c.buildCommitData(input)
 
-	content := c.buildCommitData(diff, data)
+	// The learned repository style refines the generic commit prompt
+	if input.Style != "" {
+		prompt += "\n\n" + input.Style
+	}
 
 	messages := []providers.Message{
 		{
 			Role:    "system",
 			Content: prompt,
 		},
-		{
-			Role:    "user",
-			Content: content,
-		},
 	}
 
+	// Few-shot examples show how this repository described similar changes
+	for _, example := range input.Examples {
+		messages = append(messages,
+			providers.Message{
+				Role:    "user",
+				Content: "Example from this repository's history. Changes:\n```\n" + example.Stat + "\n```",
+			},
+			providers.Message{
+				Role:    "assistant",
+				Content: example.Message,
+			},
+		)
+	}
+
+	messages = append(messages, providers.Message{
+		Role:    "user",
+		Content: content,
+	})
+
 	req := &providers.ChatRequest{
 		Model:       c.cfg.Model,
 		Messages:    messages,
@@ -62,7 +84,7 @@ func (c *Client) GenerateCommitMessage(diff string, data []string) (string, erro
 	}
 
 	ctx := context.Background()
-	resp, err := c.provider.CreateChatCompletion(ctx, req)
+	resp, err := c.complete(ctx, req)
 	if err != nil {
 		return "", fmt.Errorf("AI completion failed: %w", err)
 	}
@@ -74,17 +96,27 @@ func (c *Client) GenerateCommitMessage(diff string, data []string) (string, erro
 	return message, nil
 }
 
-func (c *Client) buildCommitData(diff string, data []string) string {
+func (c *Client) buildCommitData(input CommitInput) string {
 	var contextSection string
-	if len(data) > 0 {
-		items := make([]string, len(data))
-		for i, item := range data {
+	if len(input.Context) > 0 {
+		items := make([]string, len(input.Context))
+		for i, item := range input.Context {
 			items[i] = "- " + item
 		}
 		contextSection = "Context:\n" + strings.Join(items, "\n") + "\n\n"
 	}
 
-	return fmt.Sprintf(`%s Diff: %s`, contextSection, "```diff\n"+diff+"\n```")
+	var changesSection string
+	if input.FileChanges != "" {
+		changesSection = "File changes:\n" + input.FileChanges + "\n\n"
+	}
+
+	var historySection string
+	if input.History != "" {
+		historySection = "History:\n" + input.History + "\n\n"
+	}
+
+	return fmt.Sprintf(`%s%s%s Diff: %s`, contextSection, historySection, changesSection, "```diff\n"+input.Diff+"\n```")
 }
 
 // postProcessCommitMessage cleans up the AI-generated commit message
@@ -114,24 +146,47 @@ func (c *Client) postProcessCommitMessage(message string) string {
 }
 
 // GeneratePRDescriptionWithTemplate generates PR description using a template
-func (c *Client) GeneratePRDescriptionWithTemplate(title string, commits []string, diff string, diffStats string, templateFile string) (string, error) {
-	prompt, err := directory.LoadTemplate(c.dirs.Prompts, "draft.md")
+func (c *Client) GeneratePRDescriptionWithTemplate(input PRInput) (*PRDescription, error) {
+	template, err := c.loadTemplate(input.TemplateFile)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	if prompt == "" {
-		return "", fmt.Errorf("prompt is missing, check your 'pr description generator' prompt file (draft.md)")
+	if template == "" {
+		return nil, fmt.Errorf("template %s is empty, add the sections of the PR description to it or choose another template", input.TemplateFile)
 	}
 
-	template, err := directory.LoadTemplate(c.dirs.Templates, templateFile)
+	// Front matter configures the request and is never sent to the AI
+	meta, template, err := directory.SplitFrontMatter(template)
 	if err != nil {
-		return "", err
+		return nil, fmt.Errorf("template %s: %w", input.TemplateFile, err)
 	}
-	if template == "" {
-		return "", fmt.Errorf("prompt is missing, check your 'pr description generator' prompt file (%s)", templateFile)
+
+	// A prompt chosen by the template must exist, draft.md has a built-in fallback
+	prompt := c.loadPrompt("draft.md", DefaultDraftPrompt)
+	if meta.Prompt != "" {
+		prompt, err = directory.LoadTemplate(c.dirs.Prompts, meta.Prompt)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", config.ErrConfigMissing, err)
+		}
+		if prompt == "" {
+			return nil, fmt.Errorf("%w: prompt is missing, check your 'pr description generator' prompt file (%s)", config.ErrConfigMissing, meta.Prompt)
+		}
 	}
 
-	content := c.buildPRDescriptionData(title, commits, diff, diffStats, template)
+	if !input.PlainTemplate {
+		template, err = directory.RenderTemplate(input.TemplateFile, template, input.TemplateData, meta.BodyLine)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	content := c.buildPRDescriptionData(input, template)
+	if len(meta.RequiredSections) > 0 {
+		content += "\n\nThe description must contain these sections: " + strings.Join(meta.RequiredSections, ", ")
+	}
+	if meta.MaxLength > 0 {
+		content += fmt.Sprintf("\n\nKeep the description under %d characters.", meta.MaxLength)
+	}
 
 	messages := []providers.Message{
 		{
@@ -150,18 +205,137 @@ func (c *Client) GeneratePRDescriptionWithTemplate(title string, commits []strin
 		Temperature: c.cfg.Temperature,
 		MaxTokens:   c.cfg.MaxTokens,
 	}
+	if meta.Model != "" {
+		req.Model = meta.Model
+	}
+	if meta.Temperature != nil {
+		req.Temperature = *meta.Temperature
+	}
 
 	ctx := context.Background()
-	resp, err := c.provider.CreateChatCompletion(ctx, req)
+	resp, err := c.complete(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("AI completion failed: %w", err)
+	}
+
+	expected := markdown.Headings(markdown.Parse(template))
+	expected = append(expected, requiredSections(expected, meta.RequiredSections)...)
+
+	description, err := c.enforceSections(ctx, req, markdown.StripPlaceholders(resp.Content), expected)
+	if err != nil {
+		return nil, err
+	}
+
+	if meta.MaxLength > 0 && utf8.RuneCountInString(description) > meta.MaxLength {
+		description, err = c.shortenDescription(ctx, req, description, meta.MaxLength, expected)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &PRDescription{Body: description, Model: req.Model}, nil
+}
+
+// shortenDescription asks once to rewrite a description that is longer than
+// the max_length of its template. The limit is soft: a description that is
+// still too long is kept rather than cut in the middle of a section.
+func (c *Client) shortenDescription(ctx context.Context, req *providers.ChatRequest, description string, maxLength int, expected []markdown.Section) (string, error) {
+	length := utf8.RuneCountInString(description)
+
+	followUp := *req
+	followUp.Messages = append(append([]providers.Message{}, req.Messages...),
+		providers.Message{Role: "assistant", Content: description},
+		providers.Message{Role: "user", Content: fmt.Sprintf(shortenFollowUpPrompt, length, maxLength)},
+	)
+
+	resp, err := c.complete(ctx, &followUp)
 	if err != nil {
 		return "", fmt.Errorf("AI completion failed: %w", err)
 	}
 
-	return strings.TrimSpace(resp.Content), nil
+	shortened, err := c.enforceSections(ctx, req, markdown.StripPlaceholders(resp.Content), expected)
+	if err != nil {
+		return "", err
+	}
+	if utf8.RuneCountInString(shortened) >= length {
+		return description, nil
+	}
+	return shortened, nil
+}
+
+// enforceSections makes sure a generated description has exactly the template
+// sections. Missing sections are requested in a follow-up that only fills the
+// gaps, and the result is rebuilt with the template headings in template order.
+func (c *Client) enforceSections(ctx context.Context, req *providers.ChatRequest, description string, expected []markdown.Section) (string, error) {
+	if len(expected) == 0 {
+		return description, nil
+	}
+
+	sections := markdown.Parse(description)
+	missing, extra := markdown.CompareSections(expected, sections)
+	if len(missing) == 0 && len(extra) == 0 {
+		return description, nil
+	}
+
+	var fill []markdown.Section
+	if len(missing) > 0 {
+		headings := make([]string, len(missing))
+		for i, section := range missing {
+			headings[i] = strings.Repeat("#", section.Level) + " " + section.Heading
+		}
+		var unexpected string
+		if len(extra) > 0 {
+			unexpected = "\nThe description used these headings that are not part of the template, move their content into the sections above:\n\n" +
+				markdown.Render(extra) + "\n"
+		}
+
+		followUp := *req
+		followUp.Messages = append(append([]providers.Message{}, req.Messages...),
+			providers.Message{Role: "assistant", Content: description},
+			providers.Message{Role: "user", Content: fmt.Sprintf(sectionFollowUpPrompt, strings.Join(headings, "\n"), unexpected)},
+		)
+
+		resp, err := c.complete(ctx, &followUp)
+		if err != nil {
+			return "", fmt.Errorf("AI completion failed: %w", err)
+		}
+		fill = markdown.Parse(markdown.StripPlaceholders(resp.Content))
+	}
+
+	return markdown.Render(markdown.Restructure(expected, sections, fill, "N/A")), nil
 }
 
-func (c *Client) buildPRDescriptionData(title string, commits []string, diff string, diffStats string, template string) string {
-	return fmt.Sprintf(`PR Title: %s
+// requiredSections returns the front matter required sections that the template does not already contain
+func requiredSections(templateSections []markdown.Section, required []string) []markdown.Section {
+	level := 1
+	present := make(map[string]bool)
+	for i, section := range templateSections {
+		if i == 0 {
+			level = section.Level
+		}
+		present[section.Key()] = true
+	}
+
+	var sections []markdown.Section
+	for _, heading := range required {
+		if !present[markdown.NormalizeHeading(heading)] {
+			sections = append(sections, markdown.Section{Heading: heading, Level: level})
+		}
+	}
+	return sections
+}
+
+func (c *Client) buildPRDescriptionData(input PRInput, template string) string {
+	fileChanges := input.FileChanges
+	if fileChanges == "" {
+		fileChanges = "No renames, mode changes, binary or LFS files"
+	}
+	history := input.History
+	if history == "" {
+		history = "No history available"
+	}
+
+	data := fmt.Sprintf(`PR Title: %s
 
 		Commits in this PR:
 		%s
@@ -169,32 +343,55 @@ func (c *Client) buildPRDescriptionData(title string, commits []string, diff str
 		Change Statistics:
 		%s
 
+		File Changes:
+		%s
+
+		History:
+		%s
+
 		Code Changes:
 		%s
 
-		Template to follow (fill in the sections, keep the markdown structure):
+		Template to follow (fill in the sections, keep the markdown structure and any content already filled in):
 		%s`,
-		title,
-		strings.Join(commits, "\n"),
-		diffStats,
-		"```diff\n"+diff+"\n```",
+		input.Title,
+		strings.Join(input.Commits, "\n"),
+		input.DiffStats,
+		fileChanges,
+		history,
+		"```diff\n"+input.Diff+"\n```",
 		template)
-}
 
-// GeneratePRReview generates pre-merge PR review based on the change diffs
-func (c *Client) GeneratePRReview(diff string) (string, error) {
-	prompt, err := directory.LoadTemplate(c.dirs.Prompts, "review.md")
-	if err != nil {
-		return "", err
-	}
-	if prompt == "" {
-		return "", fmt.Errorf("prompt is missing, check your 'pr description generator' prompt file (review.md)")
+	if input.Previous != "" {
+		newCommits := strings.Join(input.NewCommits, "\n")
+		if newCommits == "" {
+			newCommits = "Unknown, compare the description with all commits"
+		}
+		data += fmt.Sprintf(`
+
+		Current description of the pull request (update the sections affected by the new commits, keep the wording of sections that are still accurate):
+		%s
+
+		New commits since the description was written:
+		%s`,
+			input.Previous,
+			newCommits)
 	}
 
+	return data
+}
+
+// GenerateReview generates a pre-merge review of the change diffs as
+// structured findings. Findings that fail validation are sent back once for
+// correction, those that are still invalid are dropped.
+func (c *Client) GenerateReview(diff string) (*review.Report, error) {
+	prompt := c.loadPrompt("review.md", DefaultReviewPrompt)
+
 	messages := []providers.Message{
 		{
 			Role: "system",
-			Content: prompt,
+			Content: prompt + "\n" + fmt.Sprintf(reviewFormatPrompt,
+				strings.Join(review.Severities, ", "), strings.Join(review.Categories, ", ")),
 		},
 		{
 			Role:    "user",
@@ -207,10 +404,174 @@ func (c *Client) GeneratePRReview(diff string) (string, error) {
 		Messages:    messages,
 		Temperature: c.cfg.Temperature,
 		MaxTokens:   c.cfg.MaxTokens,
+		JSON:        true,
+	}
+
+	ctx := context.Background()
+	resp, err := c.complete(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("AI completion failed: %w", err)
+	}
+
+	report, err := review.Parse(resp.Content)
+	var problems []string
+	if err == nil {
+		problems = report.Validate()
+	} else {
+		problems = []string{err.Error()}
+	}
+	if len(problems) == 0 {
+		return report, nil
+	}
+
+	followUp := *req
+	followUp.Messages = append(append([]providers.Message{}, req.Messages...),
+		providers.Message{Role: "assistant", Content: resp.Content},
+		providers.Message{Role: "user", Content: fmt.Sprintf(reviewFollowUpPrompt, strings.Join(problems, "\n"))},
+	)
+
+	resp, err = c.complete(ctx, &followUp)
+	if err != nil {
+		return nil, fmt.Errorf("AI completion failed: %w", err)
+	}
+
+	corrected, err := review.Parse(resp.Content)
+	switch {
+	case err == nil:
+		report = corrected
+		report.Validate()
+	case report == nil:
+		return nil, err
+	}
+	report.DropInvalid()
+	return report, nil
+}
+
+
+// SummarizeCommitStyle asks the AI to describe the commit conventions of a repository
+func (c *Client) SummarizeCommitStyle(messages []string) (string, error) {
+	prompt := c.loadPrompt("style.md", defaultStylePrompt)
+
+	req := &providers.ChatRequest{
+		Model: c.cfg.Model,
+		Messages: []providers.Message{
+			{
+				Role:    "system",
+				Content: prompt,
+			},
+			{
+				Role:    "user",
+				Content: "Commit messages:\n\n" + strings.Join(messages, "\n---\n"),
+			},
+		},
+		Temperature: c.cfg.Temperature,
+		MaxTokens:   c.cfg.MaxTokens,
+	}
+
+	ctx := context.Background()
+	resp, err := c.complete(ctx, req)
+	if err != nil {
+		return "", fmt.Errorf("AI completion failed: %w", err)
+	}
+
+	return strings.TrimSpace(resp.Content), nil
+}
+
+// GeneratePRTitle generates a pull request title from the commits, change
+// statistics and generated description. A ticket key in the branch name is
+// kept as the title prefix.
+func (c *Client) GeneratePRTitle(input PRTitleInput) (string, error) {
+	prompt := c.loadPrompt("title.md", defaultTitlePrompt)
+
+	var rules []string
+	if input.Conventional {
+		rules = append(rules, "Use the Conventional Commits form: type(scope): description, with a lowercase description")
+	}
+	if input.MaxLength > 0 {
+		rules = append(rules, fmt.Sprintf("Keep the title under %d characters", input.MaxLength))
+	}
+	if len(rules) > 0 {
+		prompt += "\n" + strings.Join(rules, "\n")
+	}
+
+	req := &providers.ChatRequest{
+		Model: c.cfg.Model,
+		Messages: []providers.Message{
+			{
+				Role:    "system",
+				Content: prompt,
+			},
+			{
+				Role: "user",
+				Content: fmt.Sprintf("Branch: %s\n\nCommits:\n%s\n\nChange Statistics:\n%s\n\nDescription:\n%s",
+					input.Branch, strings.Join(input.Commits, "\n"), input.DiffStats, input.Description),
+			},
+		},
+		Temperature: c.cfg.Temperature,
+		MaxTokens:   c.cfg.MaxTokens,
 	}
 
 	ctx := context.Background()
-	resp, err := c.provider.CreateChatCompletion(ctx, req)
+	resp, err := c.complete(ctx, req)
+	if err != nil {
+		return "", fmt.Errorf("AI completion failed: %w", err)
+	}
+
+	return formatPRTitle(resp.Content, input), nil
+}
+
+// formatPRTitle cleans up a generated title, adds the branch ticket key and
+// enforces the maximum length
+func formatPRTitle(raw string, input PRTitleInput) string {
+	title, _, _ := strings.Cut(strings.TrimSpace(raw), "\n")
+	title = strings.Trim(strings.TrimSpace(title), "`\"'#* ")
+	title = strings.TrimSuffix(title, ".")
+
+	if keys := ticket.Extract(input.Branch); len(keys) > 0 && !strings.Contains(title, keys[0]) {
+		key := keys[0]
+		if parsed, ok := conventional.Parse(title, ""); ok && input.Conventional {
+			// Keep the type and scope first so the title stays a valid Conventional Commit
+			prefix := strings.TrimSuffix(title, parsed.Description)
+			title = prefix + key + " " + parsed.Description
+		} else {
+			title = key + ": " + title
+		}
+	}
+
+	if input.MaxLength > 0 && utf8.RuneCountInString(title) > input.MaxLength {
+		runes := []rune(title)[:input.MaxLength-1]
+		cut := string(runes)
+		if space := strings.LastIndex(cut, " "); space > len(cut)/2 {
+			cut = cut[:space]
+		}
+		title = strings.TrimRight(cut, " ,;:-") + "…"
+	}
+
+	return title
+}
+
+// SummarizeRelease writes a human-readable summary of release notes
+func (c *Client) SummarizeRelease(notes string) (string, error) {
+	prompt := c.loadPrompt("changelog.md", defaultChangelogPrompt)
+
+	req := &providers.ChatRequest{
+		Model: c.cfg.Model,
+		Messages: []providers.Message{
+			{
+				Role:    "system",
+				Content: prompt,
+			},
+			{
+				Role:    "user",
+				Content: "Changes in this release:\n\n" + notes,
+			},
+		},
+		Temperature: c.cfg.Temperature,
+		MaxTokens:   c.cfg.MaxTokens,
+	}
+
+	ctx := context.Background()
+	resp, err := c.complete(ctx, req)
 	if err != nil {
 		return "", fmt.Errorf("AI completion failed: %w", err)
 	}
@@ -218,3 +579,58 @@ func (c *Client) GeneratePRReview(diff string) (string, error) {
 	return strings.TrimSpace(resp.Content), nil
 }
 
+// ClassifyCommits assigns each commit one of the categories. The result is
+// keyed by commit hash; commits the model did not classify are left out.
+func (c *Client) ClassifyCommits(commits []git.Commit, categories []string) (map[string]string, error) {
+	prompt := c.loadPrompt("classify.md", defaultClassifyPrompt)
+
+	var list strings.Builder
+	for i, commit := range commits {
+		fmt.Fprintf(&list, "%d. %s\n", i+1, commit.Subject)
+		if commit.Body != "" {
+			fmt.Fprintf(&list, "   %s\n", strings.ReplaceAll(commit.Body, "\n", "\n   "))
+		}
+	}
+
+	req := &providers.ChatRequest{
+		Model: c.cfg.Model,
+		Messages: []providers.Message{
+			{
+				Role:    "system",
+				Content: prompt,
+			},
+			{
+				Role:    "user",
+				Content: fmt.Sprintf("Categories: %s\n\nCommits:\n%s", strings.Join(categories, ", "), list.String()),
+			},
+		},
+		Temperature: 0,
+		MaxTokens:   c.cfg.MaxTokens,
+	}
+
+	ctx := context.Background()
+	resp, err := c.complete(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("AI completion failed: %w", err)
+	}
+
+	allowed := make(map[string]bool)
+	for _, category := range categories {
+		allowed[category] = true
+	}
+
+	result := make(map[string]string)
+	for _, line := range strings.Split(resp.Content, "\n") {
+		number, category, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		index, err := strconv.Atoi(strings.Trim(strings.TrimSpace(number), ".-* "))
+		category = strings.ToLower(strings.Trim(strings.TrimSpace(category), "`*\"'."))
+		if err != nil || index < 1 || index > len(commits) || !allowed[category] {
+			continue
+		}
+		result[commits[index-1].Hash] = category
+	}
+	return result, nil
+}
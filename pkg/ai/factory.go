@@ -10,7 +10,7 @@ import (
 // NewProvider creates a new AI provider based on configuration
 func NewProvider(cfg *config.AI) (providers.Provider, error) {
 	if cfg.APIKey == "" {
-		return nil, fmt.Errorf("API key is required for provider: %s", cfg.Provider)
+		return nil, fmt.Errorf("%w: API key is required for provider: %s", config.ErrConfigMissing, cfg.Provider)
 	}
 
 	switch cfg.Provider {
@@ -0,0 +1,109 @@
+package ai
+
+import (
+	"time"
+
+	"github.com/alexandrocuma/gommit/internal/git"
+	"github.com/alexandrocuma/gommit/pkg/history"
+)
+
+// CommitInput holds the repository data used to generate a commit message
+type CommitInput struct {
+	Diff        string   // Diff of the changes being committed
+	FileChanges string   // Summary of renames, mode changes, binary and LFS files
+	History     string   // Branch commit messages and recent history of the changed files
+	Style       string   // Repository commit style profile, added to the system prompt
+	Context     []string // Additional context lines such as branch and recent commits
+
+	// Past commits that touched the same files, sent as few-shot examples
+	Examples []history.Example
+}
+
+// PRInput holds the repository data used to generate a PR description
+type PRInput struct {
+	Title         string
+	Commits       []string
+	Diff          string
+	DiffStats     string
+	FileChanges   string // Summary of renames, mode changes, binary and LFS files
+	History       string // Full commit messages and recent history of the changed files
+	TemplateFile  string
+	TemplateData  *TemplateData // Repository data the template is rendered with
+	PlainTemplate bool          // Use the template as written, e.g. a repository PR template
+
+	// Set when updating an open pull request
+	Previous   string   // Current description of the pull request
+	NewCommits []string // Commits pushed since the description was last generated
+}
+
+// PRDescription is a generated pull request description
+type PRDescription struct {
+	Body  string
+	Model string // Model that wrote the description, templates may override the configured one
+}
+
+// PRTitleInput holds the data used to generate a PR title
+type PRTitleInput struct {
+	Branch       string
+	Commits      []string
+	DiffStats    string
+	Description  string // Generated PR description
+	Conventional bool   // Use the Conventional Commits form, e.g. "feat(api): add pagination"
+	MaxLength    int
+}
+
+// TemplateData is the data available to PR templates, e.g. {{.Branch}} or
+// {{range .ChangedFiles}}| {{.Path}} | +{{.Added}} -{{.Deleted}} |{{end}}
+type TemplateData struct {
+	Branch       string
+	Base         string
+	Commits      []git.Commit
+	DiffStats    string
+	Author       string
+	Tickets      []string
+	ChangedFiles []ChangedFile
+	Date         time.Time
+}
+
+// ChangedFile describes a file changed in the pull request
+type ChangedFile struct {
+	Path    string
+	OldPath string // Previous path of renamed and copied files
+	Status  string // added, modified, deleted, renamed, copied or type changed
+	Added   int
+	Deleted int
+	Binary  bool
+}
+
+// NewChangedFiles converts git file changes for use in templates
+func NewChangedFiles(changes []git.FileChange) []ChangedFile {
+	files := make([]ChangedFile, 0, len(changes))
+	for _, change := range changes {
+		files = append(files, ChangedFile{
+			Path:    change.Path,
+			OldPath: change.OldPath,
+			Status:  changeStatus(change.Status),
+			Added:   change.Added,
+			Deleted: change.Deleted,
+			Binary:  change.Binary,
+		})
+	}
+	return files
+}
+
+func changeStatus(status byte) string {
+	switch status {
+	case 'A':
+		return "added"
+	case 'D':
+		return "deleted"
+	case 'R':
+		return "renamed"
+	case 'C':
+		return "copied"
+	case 'T':
+		return "type changed"
+	default:
+		return "modified"
+	}
+}
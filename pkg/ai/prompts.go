@@ -0,0 +1,139 @@
+package ai
+
+import (
+	"github.com/alexandrocuma/gommit/pkg/directory"
+	"github.com/alexandrocuma/gommit/templates"
+)
+
+// Default prompts written by 'gommit init'. They are also used when the
+// prompts directory has no file of the same name, so gommit works without
+// running 'gommit init', e.g. when configured with GOMMIT_* variables in CI.
+const (
+	DefaultCommitPrompt = `# Commit Message Generator Prompt
+You are an expert Git commit message generator. Analyze the provided code diff and generate a concise, clear commit message following these rules:
+- Use present tense ("Add feature" not "Added feature")
+- Keep the first line under 50 characters
+- Provide a detailed description if the change is complex
+- Reference any related issues or tickets
+`
+
+	DefaultDraftPrompt = `# PR Description Generator Prompt
+You are a skilled technical writer crafting pull request descriptions. Based on the code changes provided, create a comprehensive PR description that includes:
+- Clear summary of changes
+- Motivation and context
+- Breaking changes (if any)
+- Testing instructions
+- Screenshots for UI changes (mention if applicable)
+`
+
+	DefaultReviewPrompt = `# Code Review Prompt
+You are an experienced senior software engineer conducting a thorough code review. Analyze the code changes and provide:
+- Potential bugs or errors
+- Security vulnerabilities
+- Performance issues
+- Code style improvements
+- Best practice recommendations
+- Specific line comments where relevant
+Be constructive and educational in your feedback.
+`
+)
+
+// Built-in prompts for features added after the initial setup. They are used
+// when the prompts directory has no file of the same name, so existing
+// configurations keep working without running 'gommit init' again.
+const (
+	defaultStylePrompt = `# Commit Style Analyst Prompt
+You are analyzing the commit history of a software repository. Based on the commit messages provided, describe the house style in at most five short bullet points:
+- Subject format and prefixes
+- Tone and verb mood
+- How scopes, tickets and emoji are used
+- How bodies are structured, if present
+Only describe conventions that are clearly visible in the messages.
+`
+
+	defaultTitlePrompt = `# PR Title Generator Prompt
+You write pull request titles. Based on the commits, change statistics and description provided, write a single title that summarizes the purpose of the whole pull request.
+- Use the imperative mood ("Add", "Fix", "Refactor")
+- Capitalize the first word
+- Do not end the title with a period
+- Do not wrap the title in quotes or markdown
+Respond with the title only.
+`
+
+	defaultChangelogPrompt = `# Release Notes Writer Prompt
+You write the summary of a software release for its changelog. Based on the grouped changes provided, write two to four sentences for the users of the project:
+- Lead with the most important features and fixes
+- Call out breaking changes and what users need to do
+- Do not list every change, the full list follows the summary
+- Do not use headings
+Respond with the summary only.
+`
+
+	defaultClassifyPrompt = `# Commit Classifier Prompt
+You classify commits of a software project for its release notes. For each numbered commit, choose exactly one category from the list provided.
+Respond with one line per commit in the form "<number>: <category>" and nothing else.
+`
+
+	// shortenFollowUpPrompt asks to shorten a description longer than the template max_length
+	shortenFollowUpPrompt = `The description is %d characters long, but it must be under %d characters. Rewrite the complete description under that limit, keeping the same markdown headings in the same order.`
+
+	// reviewFormatPrompt makes any review prompt answer with structured findings
+	reviewFormatPrompt = `## Response Format
+Respond only with a JSON object, regardless of any format described above:
+
+{
+  "summary": "Two or three sentences on the overall quality and risk of the changes",
+  "findings": [
+    {
+      "file": "path/of/the/file.go",
+      "start_line": 42,
+      "end_line": 45,
+      "severity": "high",
+      "category": "bug",
+      "explanation": "What is wrong and why it matters",
+      "fix": "How to fix it, code may be included"
+    }
+  ]
+}
+
+- file is the path as shown in the diff, without the a/ or b/ prefix
+- start_line and end_line are line numbers in the new version of the file
+- severity is one of: %s
+- category is one of: %s
+- Return an empty findings list when there is nothing to report
+`
+
+	// reviewFollowUpPrompt asks to correct findings that failed validation
+	reviewFollowUpPrompt = `The response is not valid:
+
+%s
+
+Respond again with the complete corrected JSON object and nothing else.`
+
+	// sectionFollowUpPrompt asks for the template sections missing from a generated description
+	sectionFollowUpPrompt = `The description does not follow the template structure. Write only the missing sections listed below, using exactly these markdown headings and nothing else:
+
+%s
+%s
+Base the sections on the changes above. Do not repeat the sections that are already present.`
+)
+
+// loadPrompt loads a prompt file from the prompts directory, falling back to
+// the built-in prompt when the file does not exist
+func (c *Client) loadPrompt(name, fallback string) string {
+	prompt, err := directory.LoadTemplate(c.dirs.Prompts, name)
+	if err != nil || prompt == "" {
+		return fallback
+	}
+	return prompt
+}
+
+// loadTemplate loads a PR template from the templates directory. The default
+// template falls back to the built-in copy when the directory does not have it.
+func (c *Client) loadTemplate(name string) (string, error) {
+	template, err := directory.LoadTemplate(c.dirs.Templates, name)
+	if err != nil && name == templates.DefaultName {
+		return templates.Default, nil
+	}
+	return template, err
+}
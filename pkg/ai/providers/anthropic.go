@@ -2,6 +2,7 @@ package providers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
@@ -66,62 +67,78 @@ func (p *AnthropicProvider) GetDefaultModel() string {
 }
 
 func (p *AnthropicProvider) CreateChatCompletion(ctx context.Context, req *ChatRequest) (*ChatResponse, error) {
-    client := anthropic.NewClient(
-			option.WithAPIKey(p.apiKey),
-		)
-    
-    // Separate system messages from conversation messages
-    var systemContent string
-    var conversationMessages []anthropic.MessageParam
-    
-    for _, msg := range req.Messages {
-        switch msg.Role {
-        case "system":
-            // Combine all system messages
-            if systemContent != "" {
-                systemContent += "\n"
-            }
-            systemContent += msg.Content
-        case "user":
-            conversationMessages = append(conversationMessages, 
-                anthropic.NewUserMessage(anthropic.NewTextBlock(msg.Content)))
-        case "assistant":
-            conversationMessages = append(conversationMessages, 
-                anthropic.NewAssistantMessage(anthropic.NewTextBlock(msg.Content)))
-        }
-    }
-    
-    // Build the request parameters
-    params := anthropic.MessageNewParams{
-        Model:     anthropic.Model(req.Model),
-        MaxTokens: int64(req.MaxTokens),
-        Messages:  conversationMessages,
-				Temperature: param.Opt[float64]{Value: req.Temperature},
-    }
-    
-    // Add system message if present
-    if systemContent != "" {
-        params.System = []anthropic.TextBlockParam{
-            {Type: "text", Text: systemContent},
-        }
-    }
-    
-    // Make the API call
-    response, err := client.Messages.New(ctx, params)
-    if err != nil {
-        return nil, fmt.Errorf("anthropic API error: %w", err)
-    }
-    
-    return &ChatResponse{
-        Content: response.Content[0].Text,
-        Usage: struct {
-            PromptTokens     int `json:"prompt_tokens"`
-            CompletionTokens int `json:"completion_tokens"`
-            TotalTokens      int `json:"total_tokens"`
-        }{
-            PromptTokens:     int(response.Usage.InputTokens),
-            CompletionTokens: int(response.Usage.OutputTokens),
-            TotalTokens:      int(response.Usage.InputTokens + response.Usage.OutputTokens),
-        },
-    }, nil
-}
\ No newline at end of file
+	client := anthropic.NewClient(
+		option.WithAPIKey(p.apiKey),
+	)
+
+	// Separate system messages from conversation messages
+	var systemContent string
+	var conversationMessages []anthropic.MessageParam
+
+	for _, msg := range req.Messages {
+		switch msg.Role {
+		case "system":
+			// Combine all system messages
+			if systemContent != "" {
+				systemContent += "\n"
+			}
+			systemContent += msg.Content
+		case "user":
+			conversationMessages = append(conversationMessages,
+				anthropic.NewUserMessage(anthropic.NewTextBlock(msg.Content)))
+		case "assistant":
+			conversationMessages = append(conversationMessages,
+				anthropic.NewAssistantMessage(anthropic.NewTextBlock(msg.Content)))
+		}
+	}
+
+	// Anthropic has no JSON mode, prefilling the answer makes it start with the object
+	if req.JSON {
+		conversationMessages = append(conversationMessages,
+			anthropic.NewAssistantMessage(anthropic.NewTextBlock("{")))
+	}
+
+	// Build the request parameters
+	params := anthropic.MessageNewParams{
+		Model:       anthropic.Model(req.Model),
+		MaxTokens:   int64(req.MaxTokens),
+		Messages:    conversationMessages,
+		Temperature: param.Opt[float64]{Value: req.Temperature},
+	}
+
+	// Add system message if present
+	if systemContent != "" {
+		params.System = []anthropic.TextBlockParam{
+			{Type: "text", Text: systemContent},
+		}
+	}
+
+	// Make the API call
+	response, err := client.Messages.New(ctx, params)
+	if err != nil {
+		statusCode := 0
+		var apiErr *anthropic.Error
+		if errors.As(err, &apiErr) {
+			statusCode = apiErr.StatusCode
+		}
+		return nil, &ProviderError{Provider: "anthropic", StatusCode: statusCode, Err: err}
+	}
+
+	content := response.Content[0].Text
+	if req.JSON {
+		content = "{" + content
+	}
+
+	return &ChatResponse{
+		Content: content,
+		Usage: struct {
+			PromptTokens     int `json:"prompt_tokens"`
+			CompletionTokens int `json:"completion_tokens"`
+			TotalTokens      int `json:"total_tokens"`
+		}{
+			PromptTokens:     int(response.Usage.InputTokens),
+			CompletionTokens: int(response.Usage.OutputTokens),
+			TotalTokens:      int(response.Usage.InputTokens + response.Usage.OutputTokens),
+		},
+	}, nil
+}
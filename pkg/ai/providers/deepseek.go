@@ -60,10 +60,13 @@ func (p *DeepSeekProvider) CreateChatCompletion(ctx context.Context, req *ChatRe
 		Temperature: float32(req.Temperature),
 		MaxTokens:   req.MaxTokens,
 	}
+	if req.JSON {
+		completionReq.ResponseFormat = &openai.ChatCompletionResponseFormat{Type: openai.ChatCompletionResponseFormatTypeJSONObject}
+	}
 
 	resp, err := p.client.CreateChatCompletion(ctx, completionReq)
 	if err != nil {
-		return nil, fmt.Errorf("DeepSeek API error: %w", err)
+		return nil, &ProviderError{Provider: "DeepSeek", StatusCode: openAIStatusCode(err), Err: err}
 	}
 
 	if len(resp.Choices) == 0 {
@@ -0,0 +1,43 @@
+package providers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+var (
+	// ErrAuth is returned when the provider rejects the configured API key
+	ErrAuth = errors.New("provider authentication failed")
+
+	// ErrQuota is returned when the provider quota or rate limit is exceeded
+	ErrQuota = errors.New("provider quota or rate limit exceeded")
+)
+
+// ProviderError wraps an error returned by a provider API together with
+// the HTTP status code, so callers can match it against ErrAuth or ErrQuota
+type ProviderError struct {
+	Provider   string
+	StatusCode int
+	Err        error
+}
+
+func (e *ProviderError) Error() string {
+	return fmt.Sprintf("%s API error: %v", e.Provider, e.Err)
+}
+
+func (e *ProviderError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether the error matches ErrAuth or ErrQuota based on the status code
+func (e *ProviderError) Is(target error) bool {
+	switch target {
+	case ErrAuth:
+		return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+	case ErrQuota:
+		return e.StatusCode == http.StatusTooManyRequests || e.StatusCode == http.StatusPaymentRequired
+	default:
+		return false
+	}
+}
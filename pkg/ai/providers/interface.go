@@ -14,6 +14,7 @@ type ChatRequest struct {
 	Messages    []Message `json:"messages"`
 	Temperature float64   `json:"temperature,omitempty"`
 	MaxTokens   int       `json:"max_tokens,omitempty"`
+	JSON        bool      `json:"json,omitempty"` // Ask for a JSON object response where the provider supports it
 }
 
 // ChatResponse represents a chat completion response
@@ -2,6 +2,7 @@ package providers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -54,10 +55,13 @@ func (p *OpenAIProvider) CreateChatCompletion(ctx context.Context, req *ChatRequ
 		Temperature: float32(req.Temperature),
 		MaxTokens:   req.MaxTokens,
 	}
+	if req.JSON {
+		completionReq.ResponseFormat = &openai.ChatCompletionResponseFormat{Type: openai.ChatCompletionResponseFormatTypeJSONObject}
+	}
 
 	resp, err := p.client.CreateChatCompletion(ctx, completionReq)
 	if err != nil {
-		return nil, fmt.Errorf("OpenAI API error: %w", err)
+		return nil, &ProviderError{Provider: "OpenAI", StatusCode: openAIStatusCode(err), Err: err}
 	}
 
 	if len(resp.Choices) == 0 {
@@ -73,3 +77,18 @@ func (p *OpenAIProvider) CreateChatCompletion(ctx context.Context, req *ChatRequ
 
 	return response, nil
 }
+
+// openAIStatusCode extracts the HTTP status code from an OpenAI-compatible API error
+func openAIStatusCode(err error) int {
+	var apiErr *openai.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.HTTPStatusCode
+	}
+
+	var reqErr *openai.RequestError
+	if errors.As(err, &reqErr) {
+		return reqErr.HTTPStatusCode
+	}
+
+	return 0
+}
@@ -0,0 +1,42 @@
+package ai
+
+import (
+	"context"
+
+	"github.com/alexandrocuma/gommit/pkg/ai/providers"
+)
+
+// Usage is the number of tokens used by the requests of a client
+type Usage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+}
+
+// complete sends a chat completion request and records its token usage
+func (c *Client) complete(ctx context.Context, req *providers.ChatRequest) (*providers.ChatResponse, error) {
+	resp, err := c.provider.CreateChatCompletion(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	c.usage.PromptTokens += resp.Usage.PromptTokens
+	c.usage.CompletionTokens += resp.Usage.CompletionTokens
+	c.usage.TotalTokens += resp.Usage.TotalTokens
+	return resp, nil
+}
+
+// Usage returns the tokens used by all requests of the client so far
+func (c *Client) Usage() Usage {
+	return c.usage
+}
+
+// Provider returns the name of the AI provider
+func (c *Client) Provider() string {
+	return c.provider.Name()
+}
+
+// Model returns the configured model
+func (c *Client) Model() string {
+	return c.cfg.Model
+}
@@ -0,0 +1,62 @@
+package changelog
+
+import (
+	"fmt"
+
+	"github.com/alexandrocuma/gommit/pkg/semver"
+)
+
+// Bump is a suggested version bump and the reasons for it
+type Bump struct {
+	Level   string
+	Reasons []string
+}
+
+// SuggestBump derives the semantic version bump from grouped changes: breaking
+// changes are major, features minor and everything else patch. Before 1.0.0
+// breaking changes only bump the minor version.
+func SuggestBump(current semver.Version, groups []Group) Bump {
+	counts := make(map[string]int)
+	examples := make(map[string]Entry)
+	for _, group := range groups {
+		counts[group.Category.Key] += len(group.Entries)
+		examples[group.Category.Key] = group.Entries[len(group.Entries)-1]
+	}
+
+	describe := func(key, noun string) string {
+		reason := fmt.Sprintf("%d %s", counts[key], noun)
+		if counts[key] != 1 {
+			reason += "s"
+		}
+		return fmt.Sprintf("%s, e.g. \"%s\" (%s)", reason, examples[key].Description, examples[key].Hash)
+	}
+
+	var bump Bump
+	switch {
+	case counts["breaking"] > 0 && current.Major == 0:
+		bump.Level = semver.Minor
+		bump.Reasons = append(bump.Reasons, describe("breaking", "breaking change"),
+			"breaking changes bump the minor version before 1.0.0")
+	case counts["breaking"] > 0:
+		bump.Level = semver.Major
+		bump.Reasons = append(bump.Reasons, describe("breaking", "breaking change"))
+	case counts["feat"] > 0:
+		bump.Level = semver.Minor
+		bump.Reasons = append(bump.Reasons, describe("feat", "new feature"))
+	default:
+		bump.Level = semver.Patch
+		if counts["fix"] > 0 {
+			bump.Reasons = append(bump.Reasons, describe("fix", "bug fix"))
+		}
+		bump.Reasons = append(bump.Reasons, "no breaking changes or new features")
+	}
+
+	// Mention the other notable changes that did not decide the level
+	if bump.Level != semver.Patch && counts["feat"] > 0 && counts["breaking"] > 0 {
+		bump.Reasons = append(bump.Reasons, describe("feat", "new feature"))
+	}
+	if bump.Level != semver.Patch && counts["fix"] > 0 {
+		bump.Reasons = append(bump.Reasons, describe("fix", "bug fix"))
+	}
+	return bump
+}
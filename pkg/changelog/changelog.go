@@ -0,0 +1,231 @@
+package changelog
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/alexandrocuma/gommit/internal/git"
+	"github.com/alexandrocuma/gommit/pkg/conventional"
+)
+
+// Output formats
+const (
+	FormatKeepAChangelog = "keepachangelog"
+	FormatGitHub         = "github"
+	FormatPlain          = "plain"
+)
+
+// Formats lists the supported output formats
+var Formats = []string{FormatKeepAChangelog, FormatGitHub, FormatPlain}
+
+// Category is a group of changes in the release notes
+type Category struct {
+	Key            string // Category identifier, e.g. feat
+	Title          string // Heading in GitHub and plain text release notes
+	KeepAChangelog string // Keep a Changelog section the category belongs to
+}
+
+// OtherCategory collects commits that do not match any other category
+const OtherCategory = "other"
+
+// Categories in the order they appear in the release notes
+var Categories = []Category{
+	{Key: "breaking", Title: "⚠️ Breaking Changes", KeepAChangelog: "Changed"},
+	{Key: "feat", Title: "🚀 Features", KeepAChangelog: "Added"},
+	{Key: "fix", Title: "🐛 Bug Fixes", KeepAChangelog: "Fixed"},
+	{Key: "perf", Title: "⚡ Performance", KeepAChangelog: "Changed"},
+	{Key: "refactor", Title: "♻️ Refactoring", KeepAChangelog: "Changed"},
+	{Key: "docs", Title: "📚 Documentation", KeepAChangelog: "Changed"},
+	{Key: "revert", Title: "⏪ Reverts", KeepAChangelog: "Removed"},
+	{Key: OtherCategory, Title: "🔧 Other Changes", KeepAChangelog: "Changed"},
+}
+
+// keepAChangelogSections is the section order defined by Keep a Changelog
+var keepAChangelogSections = []string{"Added", "Changed", "Deprecated", "Removed", "Fixed", "Security"}
+
+// Entry is a single change in the release notes
+type Entry struct {
+	Hash        string
+	Scope       string
+	Description string
+	Breaking    bool
+}
+
+// Group holds the entries of one category
+type Group struct {
+	Category Category
+	Entries  []Entry
+}
+
+// Release is a version with its grouped changes
+type Release struct {
+	Version string
+	Date    string // YYYY-MM-DD
+	Summary string // Optional human-readable summary of the release
+	Groups  []Group
+}
+
+// Classify groups commits by Conventional Commit type, oldest first. Breaking
+// changes are grouped first regardless of their type. Commits that are not
+// Conventional Commits use the category from overrides, keyed by commit hash,
+// or fall into the other category.
+func Classify(commits []git.Commit, overrides map[string]string) []Group {
+	entries := make(map[string][]Entry)
+	for i := len(commits) - 1; i >= 0; i-- {
+		commit := commits[i]
+		entry := Entry{Hash: commit.ShortHash(), Description: strings.TrimSpace(commit.Subject)}
+
+		category := OtherCategory
+		parsed, ok := conventional.Parse(commit.Subject, commit.Body)
+		if ok {
+			entry.Scope = parsed.Scope
+			entry.Description = parsed.Description
+			entry.Breaking = parsed.Breaking
+			category = parsed.Type
+		} else if override, found := overrides[commit.Hash]; found {
+			category = override
+		}
+
+		switch {
+		case entry.Breaking:
+			category = "breaking"
+		case !isCategory(category):
+			category = OtherCategory
+		}
+		entries[category] = append(entries[category], entry)
+	}
+
+	var groups []Group
+	for _, category := range Categories {
+		if len(entries[category.Key]) > 0 {
+			groups = append(groups, Group{Category: category, Entries: entries[category.Key]})
+		}
+	}
+	return groups
+}
+
+// Unclassified returns the commits that are not Conventional Commits
+func Unclassified(commits []git.Commit) []git.Commit {
+	var result []git.Commit
+	for _, commit := range commits {
+		if _, ok := conventional.Parse(commit.Subject, commit.Body); !ok {
+			result = append(result, commit)
+		}
+	}
+	return result
+}
+
+// CategoryKeys returns the keys of the categories a commit can be assigned to
+func CategoryKeys() []string {
+	var keys []string
+	for _, category := range Categories {
+		if category.Key != "breaking" {
+			keys = append(keys, category.Key)
+		}
+	}
+	return keys
+}
+
+// Render formats a release in the given format
+func Render(release Release, format string) (string, error) {
+	switch format {
+	case FormatKeepAChangelog:
+		return renderKeepAChangelog(release), nil
+	case FormatGitHub:
+		return renderGitHub(release), nil
+	case FormatPlain:
+		return renderPlain(release), nil
+	default:
+		return "", fmt.Errorf("unsupported changelog format %q, use one of: %s", format, strings.Join(Formats, ", "))
+	}
+}
+
+func renderKeepAChangelog(release Release) string {
+	var b strings.Builder
+	if release.Version == "Unreleased" {
+		b.WriteString("## [Unreleased]\n")
+	} else {
+		fmt.Fprintf(&b, "## [%s] - %s\n", release.Version, release.Date)
+	}
+	if release.Summary != "" {
+		fmt.Fprintf(&b, "\n%s\n", release.Summary)
+	}
+
+	sections := make(map[string][]string)
+	for _, group := range release.Groups {
+		for _, entry := range group.Entries {
+			line := entry.line()
+			if entry.Breaking {
+				line = "**BREAKING:** " + line
+			}
+			sections[group.Category.KeepAChangelog] = append(sections[group.Category.KeepAChangelog], line)
+		}
+	}
+	for _, section := range keepAChangelogSections {
+		if len(sections[section]) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n### %s\n\n", section)
+		for _, line := range sections[section] {
+			fmt.Fprintf(&b, "- %s\n", line)
+		}
+	}
+	return b.String()
+}
+
+func renderGitHub(release Release) string {
+	var b strings.Builder
+	if release.Summary != "" {
+		fmt.Fprintf(&b, "%s\n\n", release.Summary)
+	}
+	b.WriteString("## What's Changed\n")
+	for _, group := range release.Groups {
+		fmt.Fprintf(&b, "\n### %s\n\n", group.Category.Title)
+		for _, entry := range group.Entries {
+			fmt.Fprintf(&b, "- %s\n", entry.line())
+		}
+	}
+	return b.String()
+}
+
+func renderPlain(release Release) string {
+	var b strings.Builder
+	heading := release.Version
+	if release.Date != "" {
+		heading += " (" + release.Date + ")"
+	}
+	fmt.Fprintf(&b, "%s\n%s\n", heading, strings.Repeat("=", len([]rune(heading))))
+	if release.Summary != "" {
+		fmt.Fprintf(&b, "\n%s\n", release.Summary)
+	}
+	for _, group := range release.Groups {
+		title := strings.TrimSpace(strings.TrimLeftFunc(group.Category.Title, func(r rune) bool { return r > 0x7F }))
+		fmt.Fprintf(&b, "\n%s:\n", title)
+		for _, entry := range group.Entries {
+			scope := ""
+			if entry.Scope != "" {
+				scope = entry.Scope + ": "
+			}
+			fmt.Fprintf(&b, "  * %s%s (%s)\n", scope, entry.Description, entry.Hash)
+		}
+	}
+	return b.String()
+}
+
+// line renders an entry as a markdown list item without the bullet
+func (e Entry) line() string {
+	line := e.Description
+	if e.Scope != "" {
+		line = fmt.Sprintf("**%s:** %s", e.Scope, line)
+	}
+	return fmt.Sprintf("%s (%s)", line, e.Hash)
+}
+
+func isCategory(key string) bool {
+	for _, category := range Categories {
+		if category.Key == key {
+			return true
+		}
+	}
+	return false
+}
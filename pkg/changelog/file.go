@@ -0,0 +1,67 @@
+package changelog
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// fileHeader starts a new changelog file in the Keep a Changelog layout
+const fileHeader = `# Changelog
+
+All notable changes to this project will be documented in this file.
+
+The format is based on [Keep a Changelog](https://keepachangelog.com/en/1.1.0/),
+and this project adheres to [Semantic Versioning](https://semver.org/spec/v2.0.0.html).
+`
+
+// versionHeading matches released version headings such as "## [1.2.0] - 2025-01-31" or "## v1.2.0"
+var versionHeading = regexp.MustCompile(`(?m)^## \[?v?\d`)
+
+// unreleasedHeading matches the "## [Unreleased]" heading of a changelog
+var unreleasedHeading = regexp.MustCompile(`(?mi)^## \[?unreleased\]?[ \t]*$`)
+
+// nextHeading matches the heading that ends a release section
+var nextHeading = regexp.MustCompile(`(?m)^## `)
+
+// Prepend inserts release notes into a changelog file above the latest
+// released version, below the title and any Unreleased section. Unreleased
+// notes replace the Unreleased section of the file instead of adding another.
+// The file is created when it does not exist.
+func Prepend(path, notes string) error {
+	content, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error reading changelog %q: %w", path, err)
+	}
+
+	existing := string(content)
+	if strings.TrimSpace(existing) == "" {
+		existing = fileHeader
+	}
+	notes = strings.TrimSpace(notes) + "\n"
+
+	var updated string
+	current := unreleasedHeading.FindStringIndex(existing)
+	if current != nil && unreleasedHeading.MatchString(notes) {
+		end := len(existing)
+		if next := nextHeading.FindStringIndex(existing[current[1]:]); next != nil {
+			end = current[1] + next[0]
+		}
+		rest := strings.TrimLeft(existing[end:], "\n")
+		if rest != "" {
+			notes += "\n"
+		}
+		updated = existing[:current[0]] + notes + rest
+	} else if loc := versionHeading.FindStringIndex(existing); loc != nil {
+		updated = existing[:loc[0]] + notes + "\n" + existing[loc[0]:]
+	} else {
+		updated = strings.TrimRight(existing, "\n") + "\n\n" + notes
+	}
+
+	err = os.WriteFile(path, []byte(updated), 0644)
+	if err != nil {
+		return fmt.Errorf("error writing changelog %q: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package conventional
+
+import (
+	"regexp"
+	"strings"
+)
+
+// headerPattern matches "type(scope)!: description"
+var headerPattern = regexp.MustCompile(`^([a-zA-Z]+)(?:\(([^)]*)\))?(!)?: (.+)$`)
+
+// Types lists the commit types defined by the Conventional Commits convention and its common presets
+var Types = []string{"feat", "fix", "docs", "style", "refactor", "perf", "test", "build", "ci", "chore", "revert"}
+
+// Commit is a commit message parsed according to Conventional Commits
+type Commit struct {
+	Type        string
+	Scope       string
+	Breaking    bool
+	Description string
+}
+
+// Parse parses a commit subject and optional body. It reports false when the
+// subject does not follow the Conventional Commits format.
+func Parse(subject, body string) (Commit, bool) {
+	match := headerPattern.FindStringSubmatch(strings.TrimSpace(subject))
+	if match == nil {
+		return Commit{}, false
+	}
+
+	commit := Commit{
+		Type:        strings.ToLower(match[1]),
+		Scope:       match[2],
+		Breaking:    match[3] == "!",
+		Description: match[4],
+	}
+
+	if HasBreakingFooter(body) {
+		commit.Breaking = true
+	}
+
+	return commit, true
+}
+
+// HasBreakingFooter reports whether a commit body contains a BREAKING CHANGE footer
+func HasBreakingFooter(body string) bool {
+	for _, line := range strings.Split(body, "\n") {
+		if strings.HasPrefix(line, "BREAKING CHANGE:") || strings.HasPrefix(line, "BREAKING-CHANGE:") {
+			return true
+		}
+	}
+	return false
+}
+
+// IsKnownType reports whether t is one of the standard commit types
+func IsKnownType(t string) bool {
+	for _, known := range Types {
+		if known == t {
+			return true
+		}
+	}
+	return false
+}
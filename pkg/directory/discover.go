@@ -0,0 +1,74 @@
+package directory
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Single pull request template locations used by GitHub, relative to the repository root
+var repoTemplateFiles = []string{
+	".github/pull_request_template.md",
+	"pull_request_template.md",
+	"docs/pull_request_template.md",
+}
+
+// Directories holding multiple pull and merge request templates, relative to the repository root
+var repoTemplateDirs = []string{
+	".github/PULL_REQUEST_TEMPLATE",
+	"docs/PULL_REQUEST_TEMPLATE",
+	"PULL_REQUEST_TEMPLATE",
+	".gitlab/merge_request_templates",
+}
+
+// DiscoverRepoTemplates finds the pull and merge request templates committed to
+// a repository in the locations GitHub and GitLab read them from. File names
+// are matched case-insensitively like GitHub does. Paths are absolute.
+func DiscoverRepoTemplates(root string) []string {
+	var templates []string
+	seen := make(map[string]bool)
+	add := func(path string) {
+		if !seen[path] {
+			seen[path] = true
+			templates = append(templates, path)
+		}
+	}
+
+	for _, file := range repoTemplateFiles {
+		if path := findFold(filepath.Join(root, filepath.Dir(file)), filepath.Base(file)); path != "" {
+			add(path)
+		}
+	}
+
+	for _, dir := range repoTemplateDirs {
+		dirPath := findFold(filepath.Join(root, filepath.Dir(dir)), filepath.Base(dir))
+		if dirPath == "" || !DirExists(dirPath) {
+			continue
+		}
+		files, err := ListFilesByExtension(dirPath, ".md")
+		if err != nil {
+			continue
+		}
+		sort.Strings(files)
+		for _, file := range files {
+			add(filepath.Join(dirPath, file))
+		}
+	}
+
+	return templates
+}
+
+// findFold returns the path of the entry in dir whose name matches name case-insensitively
+func findFold(dir, name string) string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return ""
+	}
+	for _, entry := range entries {
+		if strings.EqualFold(entry.Name(), name) {
+			return filepath.Join(dir, entry.Name())
+		}
+	}
+	return ""
+}
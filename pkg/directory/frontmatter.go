@@ -0,0 +1,78 @@
+package directory
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// TemplateMeta is the optional YAML front matter of a template:
+//
+//	---
+//	description: Urgent production fixes
+//	prompt: hotfix.md
+//	model: gpt-4o-mini
+//	temperature: 0.2
+//	required_sections: [Description, Rollback Plan]
+//	max_length: 1500
+//	---
+type TemplateMeta struct {
+	Description      string   `yaml:"description"`
+	Prompt           string   `yaml:"prompt"`                      // Prompt file used instead of draft.md
+	Model            string   `yaml:"model"`                       // Model override for this template
+	Temperature      *float64 `yaml:"temperature"`                 // Temperature override, unset keeps the configured one
+	RequiredSections []string `yaml:"required_sections,omitempty"` // Headings the description must contain
+	MaxLength        int      `yaml:"max_length"`                  // Soft maximum description length in characters
+
+	// BodyLine is the line number where the template body starts, after the front matter
+	BodyLine int `yaml:"-"`
+}
+
+// SplitFrontMatter separates the YAML front matter from a template body.
+// Templates without front matter are returned unchanged with empty metadata.
+func SplitFrontMatter(content string) (TemplateMeta, string, error) {
+	meta := TemplateMeta{BodyLine: 1}
+
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	if !strings.HasPrefix(content, "---\n") {
+		return meta, content, nil
+	}
+
+	lines := strings.Split(content, "\n")
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimRight(lines[i], " ") != "---" {
+			continue
+		}
+
+		err := yaml.Unmarshal([]byte(strings.Join(lines[1:i], "\n")), &meta)
+		if err != nil {
+			return TemplateMeta{}, "", fmt.Errorf("invalid front matter: %w", err)
+		}
+		meta.BodyLine = i + 2
+		return meta, strings.Join(lines[i+1:], "\n"), nil
+	}
+
+	return TemplateMeta{}, "", fmt.Errorf("invalid front matter: missing closing '---'")
+}
+
+// LoadTemplateMeta reads the front matter of a template file in a directory
+func LoadTemplateMeta(dirPath, fileName string) (TemplateMeta, error) {
+	resolvedPath, err := ResolvePath(dirPath)
+	if err != nil {
+		return TemplateMeta{}, err
+	}
+
+	content, err := os.ReadFile(filepath.Join(resolvedPath, fileName))
+	if err != nil {
+		return TemplateMeta{}, fmt.Errorf("failed to read template file %s: %w", fileName, err)
+	}
+
+	meta, _, err := SplitFrontMatter(string(content))
+	if err != nil {
+		return TemplateMeta{}, fmt.Errorf("template %s: %w", fileName, err)
+	}
+	return meta, nil
+}
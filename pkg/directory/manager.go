@@ -186,7 +186,7 @@ func loadTemplateByName(dirPath, templateName string) (string, error) {
 		return "", err
 	}
 
-	extensions := []string{".md", ".txt", ""}
+	extensions := []string{".md", ".txt", ".tmpl", ""}
 
 	for _, ext := range extensions {
 		filename := templateName + ext
@@ -203,12 +203,12 @@ func loadTemplateByName(dirPath, templateName string) (string, error) {
 	return "", fmt.Errorf("template '%s' not found in directory '%s'", templateName, dirPath)
 }
 
+// getTemplatePaths returns the locations a template name is looked up in:
+// the templates directory, then the current directory. Repository templates
+// in .github and .gitlab are found by DiscoverRepoTemplates instead.
 func getTemplatePaths(dirPath, filename string) []string {
-	
 	return []string{
 		filepath.Join(dirPath, filename),
-		filepath.Join(".github", dirPath, filename),
-		filepath.Join(".gitlab", dirPath, filename),
 		filename,
 	}
 }
@@ -337,17 +337,17 @@ func ResolvePath(path string) (string, error) {
 	if path == "~" {
 		return os.UserHomeDir()
 	}
-	
+
 	if strings.HasPrefix(path, "~/") {
 		homeDir, err := os.UserHomeDir()
 		if err != nil {
 			return "", fmt.Errorf("failed to get home directory: %w", err)
 		}
-		
+
 		// Join home dir with the rest of the path (after "~/")
 		return filepath.Join(homeDir, path[2:]), nil
 	}
-	
+
 	// For relative or absolute paths, just clean them
 	return filepath.Clean(path), nil
 }
@@ -0,0 +1,59 @@
+package directory
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// templateErrorPattern matches the "template: name:line:col: message" prefix of text/template errors
+var templateErrorPattern = regexp.MustCompile(`^template: [^:]+:(\d+)(?::\d+)?: (.*)$`)
+
+// templateFuncs are the helper functions available in templates
+var templateFuncs = template.FuncMap{
+	"join":  strings.Join,
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+	"trim":  strings.TrimSpace,
+	"short": func(hash string) string {
+		if len(hash) > 7 {
+			return hash[:7]
+		}
+		return hash
+	},
+}
+
+// RenderTemplate executes a template with text/template before it is handed to
+// the AI, so deterministic parts are filled from repository data. Templates
+// without actions are returned unchanged. firstLine is the line of the file the
+// content starts at, so errors point at the right line after front matter.
+func RenderTemplate(name, content string, data any, firstLine int) (string, error) {
+	if !strings.Contains(content, "{{") {
+		return content, nil
+	}
+
+	tmpl, err := template.New(name).Funcs(templateFuncs).Option("missingkey=error").Parse(content)
+	if err != nil {
+		return "", templateError(name, err, firstLine)
+	}
+
+	var out bytes.Buffer
+	err = tmpl.Execute(&out, data)
+	if err != nil {
+		return "", templateError(name, err, firstLine)
+	}
+	return out.String(), nil
+}
+
+// templateError rewrites text/template errors as "template NAME, line N: message"
+func templateError(name string, err error, firstLine int) error {
+	match := templateErrorPattern.FindStringSubmatch(err.Error())
+	if match == nil {
+		return fmt.Errorf("template %s: %w", name, err)
+	}
+	line, _ := strconv.Atoi(match[1])
+	return fmt.Errorf("template %s, line %d: %s", name, line+firstLine-1, match[2])
+}
@@ -0,0 +1,79 @@
+package forge
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/alexandrocuma/gommit/internal/config"
+)
+
+// NewForge creates a forge client for the repository behind a git remote URL
+func NewForge(cfg *config.Forge, remoteURL string) (Forge, error) {
+	remote, err := ParseRemoteURL(remoteURL)
+	if err != nil {
+		return nil, err
+	}
+
+	switch DetectProvider(cfg, remote) {
+	case "github":
+		token := firstNonEmpty(cfg.GitHub.Token, os.Getenv("GOMMIT_GITHUB_TOKEN"), os.Getenv("GITHUB_TOKEN"), os.Getenv("GH_TOKEN"))
+		if token == "" {
+			return nil, fmt.Errorf("%w: GitHub token not configured, set forge.github.token or GITHUB_TOKEN", config.ErrConfigMissing)
+		}
+		return NewGitHubForge(gitHubAPIURL(cfg, remote), token, remote.Owner, remote.Repo), nil
+	case "gitlab":
+		token := firstNonEmpty(cfg.GitLab.Token, os.Getenv("GOMMIT_GITLAB_TOKEN"), os.Getenv("GITLAB_TOKEN"))
+		if token == "" {
+			return nil, fmt.Errorf("%w: GitLab token not configured, set forge.gitlab.token or GITLAB_TOKEN", config.ErrConfigMissing)
+		}
+		return NewGitLabForge(gitLabAPIURL(cfg, remote), token, remote.Path()), nil
+	default:
+		return nil, fmt.Errorf("%w: cannot detect the forge of %s, set forge.provider", ErrUnsupported, remote.Host)
+	}
+}
+
+// DetectProvider returns the configured forge provider or guesses it from the remote host
+func DetectProvider(cfg *config.Forge, remote Remote) string {
+	if cfg.Provider != "" {
+		return strings.ToLower(cfg.Provider)
+	}
+
+	host := strings.ToLower(remote.Host)
+	switch {
+	case strings.Contains(host, "github"):
+		return "github"
+	case strings.Contains(host, "gitlab"):
+		return "gitlab"
+	default:
+		return ""
+	}
+}
+
+// gitHubAPIURL returns the configured API URL, or the public or Enterprise Server default
+func gitHubAPIURL(cfg *config.Forge, remote Remote) string {
+	if cfg.GitHub.APIURL != "" {
+		return cfg.GitHub.APIURL
+	}
+	if remote.Host == "github.com" {
+		return "https://api.github.com"
+	}
+	return fmt.Sprintf("https://%s/api/v3", remote.Host)
+}
+
+// gitLabAPIURL returns the configured API URL, or the v4 API of the remote host
+func gitLabAPIURL(cfg *config.Forge, remote Remote) string {
+	if cfg.GitLab.APIURL != "" {
+		return cfg.GitLab.APIURL
+	}
+	return fmt.Sprintf("https://%s/api/v4", remote.Host)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
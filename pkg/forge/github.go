@@ -0,0 +1,153 @@
+package forge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+type GitHubForge struct {
+	api   *apiClient
+	owner string
+	repo  string
+}
+
+type gitHubPullRequest struct {
+	Number  int    `json:"number"`
+	Title   string `json:"title"`
+	Body    string `json:"body"`
+	HTMLURL string `json:"html_url"`
+	Draft   bool   `json:"draft"`
+	Head    struct {
+		Ref string `json:"ref"`
+		SHA string `json:"sha"`
+	} `json:"head"`
+	Base struct {
+		Ref string `json:"ref"`
+	} `json:"base"`
+}
+
+func NewGitHubForge(apiURL, token, owner, repo string) *GitHubForge {
+	return &GitHubForge{
+		api: &apiClient{
+			forge:   "GitHub",
+			baseURL: apiURL,
+			headers: map[string]string{
+				"Authorization":        "Bearer " + token,
+				"Accept":               "application/vnd.github+json",
+				"X-GitHub-Api-Version": "2022-11-28",
+			},
+			httpClient: &http.Client{Timeout: 30 * time.Second},
+		},
+		owner: owner,
+		repo:  repo,
+	}
+}
+
+func (f *GitHubForge) Name() string {
+	return "github"
+}
+
+func (f *GitHubForge) CreatePullRequest(ctx context.Context, req *CreateRequest) (*PullRequest, error) {
+	payload := map[string]any{
+		"title": req.Title,
+		"body":  req.Body,
+		"head":  req.Head,
+		"base":  req.Base,
+		"draft": req.Draft,
+	}
+
+	var created gitHubPullRequest
+	err := f.api.do(ctx, http.MethodPost, f.repoPath("/pulls"), payload, &created)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pull request: %w", err)
+	}
+
+	// The pull request exists from here on, it is returned with the errors of
+	// the reviewers, labels and assignees that could not be applied
+	var errs []error
+	if len(req.Reviewers) > 0 {
+		err = f.api.do(ctx, http.MethodPost, f.repoPath(fmt.Sprintf("/pulls/%d/requested_reviewers", created.Number)),
+			map[string]any{"reviewers": req.Reviewers}, nil)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to request reviewers: %w", err))
+		}
+	}
+
+	// Labels and assignees are managed through the issues API
+	if len(req.Labels) > 0 {
+		err = f.api.do(ctx, http.MethodPost, f.repoPath(fmt.Sprintf("/issues/%d/labels", created.Number)),
+			map[string]any{"labels": req.Labels}, nil)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to add labels: %w", err))
+		}
+	}
+
+	if len(req.Assignees) > 0 {
+		err = f.api.do(ctx, http.MethodPost, f.repoPath(fmt.Sprintf("/issues/%d/assignees", created.Number)),
+			map[string]any{"assignees": req.Assignees}, nil)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to add assignees: %w", err))
+		}
+	}
+
+	pr := created.toPullRequest()
+	if len(errs) > 0 {
+		return pr, fmt.Errorf("pull request #%d was opened at %s but %w", pr.Number, pr.URL, errors.Join(errs...))
+	}
+	return pr, nil
+}
+
+func (f *GitHubForge) FindPullRequest(ctx context.Context, head string) (*PullRequest, error) {
+	query := url.Values{}
+	query.Set("head", f.owner+":"+head)
+	query.Set("state", "open")
+
+	var pulls []gitHubPullRequest
+	err := f.api.do(ctx, http.MethodGet, f.repoPath("/pulls?"+query.Encode()), nil, &pulls)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pull request: %w", err)
+	}
+	if len(pulls) == 0 {
+		return nil, nil
+	}
+	return pulls[0].toPullRequest(), nil
+}
+
+func (f *GitHubForge) UpdatePullRequest(ctx context.Context, number int, req *UpdateRequest) (*PullRequest, error) {
+	payload := map[string]any{}
+	if req.Title != "" {
+		payload["title"] = req.Title
+	}
+	if req.Body != "" {
+		payload["body"] = req.Body
+	}
+
+	var updated gitHubPullRequest
+	err := f.api.do(ctx, http.MethodPatch, f.repoPath(fmt.Sprintf("/pulls/%d", number)), payload, &updated)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update pull request: %w", err)
+	}
+	return updated.toPullRequest(), nil
+}
+
+// repoPath builds an API path below /repos/{owner}/{repo}
+func (f *GitHubForge) repoPath(path string) string {
+	return fmt.Sprintf("/repos/%s/%s%s", f.owner, f.repo, path)
+}
+
+func (pr *gitHubPullRequest) toPullRequest() *PullRequest {
+	return &PullRequest{
+		Number:  pr.Number,
+		Title:   pr.Title,
+		Body:    pr.Body,
+		Head:    pr.Head.Ref,
+		Base:    pr.Base.Ref,
+		URL:     pr.HTMLURL,
+		Draft:   pr.Draft,
+		HeadSHA: pr.Head.SHA,
+	}
+}
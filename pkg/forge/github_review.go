@@ -0,0 +1,105 @@
+package forge
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// reviewCommentsPerPage is the largest page size the GitHub API allows for reviews and comments
+const reviewCommentsPerPage = 100
+
+type gitHubReview struct {
+	CommitID string `json:"commit_id"`
+	Body     string `json:"body"`
+	State    string `json:"state"`
+}
+
+type gitHubReviewComment struct {
+	Path      string `json:"path"`
+	Line      int    `json:"line,omitempty"`
+	Side      string `json:"side,omitempty"`
+	StartLine int    `json:"start_line,omitempty"`
+	StartSide string `json:"start_side,omitempty"`
+	Body      string `json:"body"`
+}
+
+func (f *GitHubForge) GetPullRequest(ctx context.Context, number int) (*PullRequest, error) {
+	var pull gitHubPullRequest
+	err := f.api.do(ctx, http.MethodGet, f.repoPath(fmt.Sprintf("/pulls/%d", number)), nil, &pull)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request #%d: %w", number, err)
+	}
+	return pull.toPullRequest(), nil
+}
+
+func (f *GitHubForge) ListReviews(ctx context.Context, number int) ([]Review, error) {
+	var reviews []Review
+	for page := 1; ; page++ {
+		var batch []gitHubReview
+		path := fmt.Sprintf("/pulls/%d/reviews?per_page=%d&page=%d", number, reviewCommentsPerPage, page)
+		err := f.api.do(ctx, http.MethodGet, f.repoPath(path), nil, &batch)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list reviews: %w", err)
+		}
+
+		for _, review := range batch {
+			reviews = append(reviews, Review{CommitSHA: review.CommitID, Body: review.Body, Event: review.State})
+		}
+		if len(batch) < reviewCommentsPerPage {
+			return reviews, nil
+		}
+	}
+}
+
+func (f *GitHubForge) ListReviewComments(ctx context.Context, number int) ([]ReviewComment, error) {
+	var comments []ReviewComment
+	for page := 1; ; page++ {
+		var batch []gitHubReviewComment
+		path := fmt.Sprintf("/pulls/%d/comments?per_page=%d&page=%d", number, reviewCommentsPerPage, page)
+		err := f.api.do(ctx, http.MethodGet, f.repoPath(path), nil, &batch)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list review comments: %w", err)
+		}
+
+		for _, comment := range batch {
+			comments = append(comments, ReviewComment{
+				Path:      comment.Path,
+				StartLine: comment.StartLine,
+				Line:      comment.Line,
+				Body:      comment.Body,
+			})
+		}
+		if len(batch) < reviewCommentsPerPage {
+			return comments, nil
+		}
+	}
+}
+
+func (f *GitHubForge) CreateReview(ctx context.Context, number int, review *Review) error {
+	comments := make([]gitHubReviewComment, len(review.Comments))
+	for i, comment := range review.Comments {
+		comments[i] = gitHubReviewComment{
+			Path: comment.Path,
+			Line: comment.Line,
+			Side: "RIGHT",
+			Body: comment.Body,
+		}
+		if comment.StartLine > 0 && comment.StartLine < comment.Line {
+			comments[i].StartLine = comment.StartLine
+			comments[i].StartSide = "RIGHT"
+		}
+	}
+
+	payload := map[string]any{
+		"commit_id": review.CommitSHA,
+		"body":      review.Body,
+		"event":     review.Event,
+		"comments":  comments,
+	}
+	err := f.api.do(ctx, http.MethodPost, f.repoPath(fmt.Sprintf("/pulls/%d/reviews", number)), payload, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create review: %w", err)
+	}
+	return nil
+}
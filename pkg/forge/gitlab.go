@@ -0,0 +1,161 @@
+package forge
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+type GitLabForge struct {
+	api     *apiClient
+	project string // URL-encoded project path, e.g. group%2Fsubgroup%2Frepo
+}
+
+type gitLabMergeRequest struct {
+	IID          int    `json:"iid"`
+	Title        string `json:"title"`
+	Description  string `json:"description"`
+	WebURL       string `json:"web_url"`
+	Draft        bool   `json:"draft"`
+	SourceBranch string `json:"source_branch"`
+	TargetBranch string `json:"target_branch"`
+	SHA          string `json:"sha"`
+}
+
+type gitLabUser struct {
+	ID       int    `json:"id"`
+	Username string `json:"username"`
+}
+
+func NewGitLabForge(apiURL, token, projectPath string) *GitLabForge {
+	return &GitLabForge{
+		api: &apiClient{
+			forge:   "GitLab",
+			baseURL: apiURL,
+			headers: map[string]string{
+				"PRIVATE-TOKEN": token,
+			},
+			httpClient: &http.Client{Timeout: 30 * time.Second},
+		},
+		project: url.PathEscape(projectPath),
+	}
+}
+
+func (f *GitLabForge) Name() string {
+	return "gitlab"
+}
+
+func (f *GitLabForge) CreatePullRequest(ctx context.Context, req *CreateRequest) (*PullRequest, error) {
+	title := req.Title
+	if req.Draft && !strings.HasPrefix(title, "Draft:") {
+		title = "Draft: " + title
+	}
+
+	payload := map[string]any{
+		"title":                title,
+		"description":          req.Body,
+		"source_branch":        req.Head,
+		"target_branch":        req.Base,
+		"squash":               req.Squash,
+		"remove_source_branch": req.RemoveSourceBranch,
+	}
+	if len(req.Labels) > 0 {
+		payload["labels"] = strings.Join(req.Labels, ",")
+	}
+
+	// GitLab assigns users by ID, usernames are resolved first
+	if len(req.Reviewers) > 0 {
+		ids, err := f.userIDs(ctx, req.Reviewers)
+		if err != nil {
+			return nil, err
+		}
+		payload["reviewer_ids"] = ids
+	}
+	if len(req.Assignees) > 0 {
+		ids, err := f.userIDs(ctx, req.Assignees)
+		if err != nil {
+			return nil, err
+		}
+		payload["assignee_ids"] = ids
+	}
+
+	var created gitLabMergeRequest
+	err := f.api.do(ctx, http.MethodPost, f.projectPath("/merge_requests"), payload, &created)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create merge request: %w", err)
+	}
+	return created.toPullRequest(), nil
+}
+
+func (f *GitLabForge) FindPullRequest(ctx context.Context, head string) (*PullRequest, error) {
+	query := url.Values{}
+	query.Set("source_branch", head)
+	query.Set("state", "opened")
+
+	var requests []gitLabMergeRequest
+	err := f.api.do(ctx, http.MethodGet, f.projectPath("/merge_requests?"+query.Encode()), nil, &requests)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find merge request: %w", err)
+	}
+	if len(requests) == 0 {
+		return nil, nil
+	}
+	return requests[0].toPullRequest(), nil
+}
+
+func (f *GitLabForge) UpdatePullRequest(ctx context.Context, number int, req *UpdateRequest) (*PullRequest, error) {
+	payload := map[string]any{}
+	if req.Title != "" {
+		payload["title"] = req.Title
+	}
+	if req.Body != "" {
+		payload["description"] = req.Body
+	}
+
+	var updated gitLabMergeRequest
+	err := f.api.do(ctx, http.MethodPut, f.projectPath(fmt.Sprintf("/merge_requests/%d", number)), payload, &updated)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update merge request: %w", err)
+	}
+	return updated.toPullRequest(), nil
+}
+
+// userIDs looks up the IDs of GitLab users by username
+func (f *GitLabForge) userIDs(ctx context.Context, usernames []string) ([]int, error) {
+	ids := make([]int, 0, len(usernames))
+	for _, username := range usernames {
+		username = strings.TrimPrefix(username, "@")
+
+		var users []gitLabUser
+		err := f.api.do(ctx, http.MethodGet, "/users?username="+url.QueryEscape(username), nil, &users)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up GitLab user %q: %w", username, err)
+		}
+		if len(users) == 0 {
+			return nil, fmt.Errorf("GitLab user %q not found", username)
+		}
+		ids = append(ids, users[0].ID)
+	}
+	return ids, nil
+}
+
+// projectPath builds an API path below /projects/{id}
+func (f *GitLabForge) projectPath(path string) string {
+	return "/projects/" + f.project + path
+}
+
+func (mr *gitLabMergeRequest) toPullRequest() *PullRequest {
+	return &PullRequest{
+		Number:  mr.IID,
+		Title:   mr.Title,
+		Body:    mr.Description,
+		Head:    mr.SourceBranch,
+		Base:    mr.TargetBranch,
+		URL:     mr.WebURL,
+		Draft:   mr.Draft,
+		HeadSHA: mr.SHA,
+	}
+}
@@ -0,0 +1,105 @@
+package forge
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// APIError is returned when a forge API responds with an error status
+type APIError struct {
+	Forge      string
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s API error (%d): %s", e.Forge, e.StatusCode, e.Message)
+}
+
+// Is reports whether the error matches ErrAuth based on the status code
+func (e *APIError) Is(target error) bool {
+	return target == ErrAuth && (e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden)
+}
+
+// apiClient performs JSON requests against a forge REST API
+type apiClient struct {
+	forge      string
+	baseURL    string
+	headers    map[string]string
+	httpClient *http.Client
+}
+
+// do sends a JSON request and decodes the JSON response into out when not nil
+func (c *apiClient) do(ctx context.Context, method, path string, in, out any) error {
+	var body io.Reader
+	if in != nil {
+		payload, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("failed to encode %s request: %w", c.forge, err)
+		}
+		body = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimRight(c.baseURL, "/")+path, body)
+	if err != nil {
+		return fmt.Errorf("failed to create %s request: %w", c.forge, err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for key, value := range c.headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s API request failed: %w", c.forge, err)
+	}
+	defer resp.Body.Close()
+
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read %s response: %w", c.forge, err)
+	}
+
+	if resp.StatusCode >= 300 {
+		return &APIError{Forge: c.forge, StatusCode: resp.StatusCode, Message: errorMessage(content)}
+	}
+
+	if out != nil && len(content) > 0 {
+		err = json.Unmarshal(content, out)
+		if err != nil {
+			return fmt.Errorf("failed to decode %s response: %w", c.forge, err)
+		}
+	}
+	return nil
+}
+
+// errorMessage extracts a readable message from a forge error response
+func errorMessage(content []byte) string {
+	var payload struct {
+		Message any `json:"message"`
+		Error   any `json:"error"`
+		Errors  any `json:"errors"`
+	}
+	if json.Unmarshal(content, &payload) != nil {
+		return strings.TrimSpace(string(content))
+	}
+
+	var parts []string
+	for _, value := range []any{payload.Message, payload.Error, payload.Errors} {
+		if value != nil {
+			parts = append(parts, fmt.Sprint(value))
+		}
+	}
+	if len(parts) == 0 {
+		return strings.TrimSpace(string(content))
+	}
+	return strings.Join(parts, ": ")
+}
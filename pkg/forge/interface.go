@@ -0,0 +1,103 @@
+package forge
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	// ErrAuth is returned when the forge rejects or is missing the API token
+	ErrAuth = errors.New("forge authentication failed")
+
+	// ErrUnsupported is returned when the remote is hosted on an unsupported forge
+	ErrUnsupported = errors.New("unsupported forge")
+)
+
+// PullRequest represents a GitHub pull request or a GitLab merge request
+type PullRequest struct {
+	Number  int
+	Title   string
+	Body    string
+	Head    string
+	Base    string
+	URL     string
+	Draft   bool
+	HeadSHA string
+}
+
+// CreateRequest holds the data used to open a pull request
+type CreateRequest struct {
+	Title     string
+	Body      string
+	Head      string
+	Base      string
+	Draft     bool
+	Reviewers []string
+	Labels    []string
+	Assignees []string
+
+	// GitLab merge request options
+	Squash             bool
+	RemoveSourceBranch bool
+}
+
+// UpdateRequest holds the fields to change on an existing pull request,
+// empty fields are left untouched
+type UpdateRequest struct {
+	Title string
+	Body  string
+}
+
+// Review events
+const (
+	ReviewEventComment        = "COMMENT"
+	ReviewEventRequestChanges = "REQUEST_CHANGES"
+)
+
+// Review is a pull request review with a summary and inline comments
+type Review struct {
+	CommitSHA string // Head commit the comments refer to
+	Body      string
+	Event     string
+	Comments  []ReviewComment
+}
+
+// ReviewComment is an inline comment on the new side of a pull request diff
+type ReviewComment struct {
+	Path      string
+	StartLine int // First line of a multi-line comment, 0 for a single line
+	Line      int
+	Body      string
+}
+
+// Forge defines the interface for code hosting platforms
+type Forge interface {
+	// Name returns the forge name
+	Name() string
+
+	// CreatePullRequest opens a pull request and applies reviewers, labels and
+	// assignees. When the pull request was opened but some of them could not be
+	// applied, it is returned along with the error.
+	CreatePullRequest(ctx context.Context, req *CreateRequest) (*PullRequest, error)
+
+	// FindPullRequest returns the open pull request for a head branch, or nil when there is none
+	FindPullRequest(ctx context.Context, head string) (*PullRequest, error)
+
+	// UpdatePullRequest edits the title and description of an existing pull request
+	UpdatePullRequest(ctx context.Context, number int, req *UpdateRequest) (*PullRequest, error)
+}
+
+// Reviewer is implemented by forges that accept pull request reviews with inline comments
+type Reviewer interface {
+	// GetPullRequest returns a pull request by number
+	GetPullRequest(ctx context.Context, number int) (*PullRequest, error)
+
+	// ListReviews returns the reviews submitted on a pull request, without their comments
+	ListReviews(ctx context.Context, number int) ([]Review, error)
+
+	// ListReviewComments returns the inline comments posted on a pull request
+	ListReviewComments(ctx context.Context, number int) ([]ReviewComment, error)
+
+	// CreateReview submits a review with its inline comments at once
+	CreateReview(ctx context.Context, number int, review *Review) error
+}
@@ -0,0 +1,57 @@
+package forge
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Remote identifies a repository on a forge, parsed from a git remote URL
+type Remote struct {
+	Host  string
+	Owner string // Owner, organization or (nested) group
+	Repo  string
+}
+
+// Path returns the full repository path, e.g. owner/repo or group/subgroup/repo
+func (r Remote) Path() string {
+	return r.Owner + "/" + r.Repo
+}
+
+// ParseRemoteURL parses SSH (git@host:owner/repo.git), ssh:// and http(s) remote URLs
+func ParseRemoteURL(remoteURL string) (Remote, error) {
+	remoteURL = strings.TrimSpace(remoteURL)
+
+	var host, repoPath string
+	switch {
+	case strings.Contains(remoteURL, "://"):
+		parsed, err := url.Parse(remoteURL)
+		if err != nil {
+			return Remote{}, fmt.Errorf("invalid remote URL %q: %w", remoteURL, err)
+		}
+		host = parsed.Hostname()
+		repoPath = parsed.Path
+	case strings.Contains(remoteURL, ":"):
+		// scp-like syntax: [user@]host:path
+		userHost, path, _ := strings.Cut(remoteURL, ":")
+		if at := strings.LastIndex(userHost, "@"); at != -1 {
+			userHost = userHost[at+1:]
+		}
+		host = userHost
+		repoPath = path
+	default:
+		return Remote{}, fmt.Errorf("unsupported remote URL %q", remoteURL)
+	}
+
+	repoPath = strings.TrimSuffix(strings.Trim(repoPath, "/"), ".git")
+	slash := strings.LastIndex(repoPath, "/")
+	if host == "" || slash <= 0 || slash == len(repoPath)-1 {
+		return Remote{}, fmt.Errorf("cannot determine repository from remote URL %q", remoteURL)
+	}
+
+	return Remote{
+		Host:  host,
+		Owner: repoPath[:slash],
+		Repo:  repoPath[slash+1:],
+	}, nil
+}
@@ -0,0 +1,105 @@
+package history
+
+import (
+	"path"
+	"sort"
+
+	"github.com/alexandrocuma/gommit/internal/config"
+	"github.com/alexandrocuma/gommit/internal/git"
+)
+
+// exampleCandidates is how many past commits are scored per lookup
+const exampleCandidates = 30
+
+// ExampleSource provides the past commits used as few-shot examples
+type ExampleSource interface {
+	GetCommitsTouching(paths []string, count int) ([]git.Commit, error)
+	GetCommitStat(hash string) (string, error)
+}
+
+// Example is a past commit shown to the AI as a sample of how changes are described
+type Example struct {
+	Stat    string
+	Message string
+}
+
+// SelectExamples picks past commits that touched the same files or packages as
+// the current change, best matches first, within the configured count and token budget
+func SelectExamples(src ExampleSource, paths []string, cfg config.History) []Example {
+	if cfg.Examples <= 0 || len(paths) == 0 {
+		return nil
+	}
+
+	changedFiles := make(map[string]bool)
+	changedDirs := make(map[string]bool)
+	var dirs []string
+	for _, p := range paths {
+		changedFiles[p] = true
+		// Top-level files would match the whole repository as a package
+		dir := path.Dir(p)
+		if dir != "." && !changedDirs[dir] {
+			changedDirs[dir] = true
+			dirs = append(dirs, dir)
+		}
+	}
+
+	// Commits touching the same files rank first, then the same packages
+	candidates := make(map[string]git.Commit)
+	var order []string
+	for _, pathspecs := range [][]string{paths, dirs} {
+		if len(pathspecs) == 0 {
+			continue
+		}
+		commits, err := src.GetCommitsTouching(pathspecs, exampleCandidates)
+		if err != nil {
+			continue
+		}
+		for _, commit := range commits {
+			if _, ok := candidates[commit.Hash]; !ok {
+				candidates[commit.Hash] = commit
+				order = append(order, commit.Hash)
+			}
+		}
+	}
+
+	scores := make(map[string]int)
+	for _, hash := range order {
+		for _, file := range candidates[hash].Files {
+			if changedFiles[file] {
+				scores[hash] += 2
+			} else if changedDirs[path.Dir(file)] {
+				scores[hash]++
+			}
+		}
+	}
+
+	// Stable sort keeps the most recent commit first among equal scores
+	sort.SliceStable(order, func(i, j int) bool {
+		return scores[order[i]] > scores[order[j]]
+	})
+
+	budget := NewBudget(cfg.ExampleTokens)
+	var examples []Example
+	for _, hash := range order {
+		if len(examples) == cfg.Examples || scores[hash] == 0 {
+			break
+		}
+
+		commit := candidates[hash]
+		stat, err := src.GetCommitStat(hash)
+		if err != nil {
+			continue
+		}
+
+		message := commit.Subject
+		if commit.Body != "" {
+			message += "\n\n" + commit.Body
+		}
+		if !budget.Take(stat + message) {
+			continue
+		}
+		examples = append(examples, Example{Stat: stat, Message: message})
+	}
+
+	return examples
+}
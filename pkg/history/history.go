@@ -0,0 +1,133 @@
+package history
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/alexandrocuma/gommit/internal/config"
+	"github.com/alexandrocuma/gommit/internal/git"
+)
+
+// Source provides the git history used to build the prompt context
+type Source interface {
+	GetCommitDetailsBetweenBranches(baseBranch, compareBranch string) ([]git.Commit, error)
+	GetFileHistory(rev, path string, count int) ([]git.Commit, error)
+}
+
+// Request describes which history to gather
+type Request struct {
+	BranchBase string   // Branch commits are gathered from BranchBase..BranchHead
+	BranchHead string   // Leave BranchBase empty to skip the branch commits
+	FileRev    string   // Revision from which the per-file history is read
+	Paths      []string // Changed files to gather the recent history for
+}
+
+// Gather collects the full messages of the branch commits and the recent
+// commits touching each changed file, and renders them within the token budget.
+// History that cannot be read is left out of the context and reported in the
+// returned error, the context of the remaining history is still returned.
+func Gather(src Source, req Request, cfg config.History) (string, error) {
+	var errs []error
+
+	var branchCommits []git.Commit
+	if req.BranchBase != "" && req.BranchHead != "" {
+		commits, err := src.GetCommitDetailsBetweenBranches(req.BranchBase, req.BranchHead)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		branchCommits = commits
+	}
+
+	fileHistory := make(map[string][]git.Commit)
+	if cfg.FileCommits > 0 && req.FileRev != "" {
+		for _, path := range req.Paths {
+			commits, err := src.GetFileHistory(req.FileRev, path, cfg.FileCommits)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			if len(commits) > 0 {
+				fileHistory[path] = commits
+			}
+		}
+	}
+
+	return Render(branchCommits, req.Paths, fileHistory, cfg), errors.Join(errs...)
+}
+
+// Render formats the branch commits and per-file history as prompt context.
+// Branch commits come first, then file history in path order; entries that do
+// not fit in cfg.MaxTokens are dropped and commits are only shown once.
+func Render(branchCommits []git.Commit, paths []string, fileHistory map[string][]git.Commit, cfg config.History) string {
+	budget := NewBudget(cfg.MaxTokens)
+	seen := make(map[string]bool)
+
+	var sb strings.Builder
+	if len(branchCommits) > 0 && budget.Take("Commits on this branch:\n") {
+		sb.WriteString("Commits on this branch:\n")
+		for _, commit := range branchCommits {
+			entry := formatCommit(commit, cfg.IncludeAuthors, false)
+			if !budget.Take(entry) {
+				break
+			}
+			seen[commit.Hash] = true
+			sb.WriteString(entry)
+		}
+	}
+
+	for _, path := range paths {
+		var commits []git.Commit
+		var entries []string
+		for _, commit := range fileHistory[path] {
+			if seen[commit.Hash] {
+				continue
+			}
+			commits = append(commits, commit)
+			entries = append(entries, formatCommit(commit, cfg.IncludeAuthors, true))
+		}
+		if len(entries) == 0 {
+			continue
+		}
+
+		header := fmt.Sprintf("\nRecent commits touching %s:\n", path)
+		if !budget.Take(header + entries[0]) {
+			break
+		}
+		seen[commits[0].Hash] = true
+		sb.WriteString(header + entries[0])
+		for i, entry := range entries[1:] {
+			if !budget.Take(entry) {
+				break
+			}
+			seen[commits[i+1].Hash] = true
+			sb.WriteString(entry)
+		}
+	}
+
+	return strings.TrimSpace(sb.String())
+}
+
+// formatCommit renders a commit as a list item with its indented body
+func formatCommit(commit git.Commit, includeAuthor, includeDate bool) string {
+	var meta []string
+	if includeAuthor && commit.Author != "" {
+		meta = append(meta, commit.Author)
+	}
+	if includeDate && !commit.Date.IsZero() {
+		meta = append(meta, commit.Date.Format("2006-01-02"))
+	}
+
+	line := fmt.Sprintf("- %s %s", commit.ShortHash(), commit.Subject)
+	if len(meta) > 0 {
+		line += " (" + strings.Join(meta, ", ") + ")"
+	}
+	line += "\n"
+
+	if commit.Body != "" {
+		for _, bodyLine := range strings.Split(commit.Body, "\n") {
+			line += "  " + bodyLine + "\n"
+		}
+	}
+	return line
+}
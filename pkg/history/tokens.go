@@ -0,0 +1,34 @@
+package history
+
+// charsPerToken is a rough average for English text and code across providers
+const charsPerToken = 4
+
+// EstimateTokens approximates the number of tokens a text uses in a prompt
+func EstimateTokens(text string) int {
+	return (len(text) + charsPerToken - 1) / charsPerToken
+}
+
+// Budget tracks the remaining tokens available for optional prompt context
+type Budget struct {
+	remaining int
+}
+
+// NewBudget creates a budget of the given number of tokens
+func NewBudget(tokens int) *Budget {
+	return &Budget{remaining: tokens}
+}
+
+// Take reserves the tokens needed for text and reports whether they fit
+func (b *Budget) Take(text string) bool {
+	tokens := EstimateTokens(text)
+	if tokens > b.remaining {
+		return false
+	}
+	b.remaining -= tokens
+	return true
+}
+
+// Remaining returns the number of tokens left in the budget
+func (b *Budget) Remaining() int {
+	return b.remaining
+}
@@ -7,41 +7,11 @@ import (
 	"strings"
 
 	"github.com/alexandrocuma/gommit/internal/config"
+	"github.com/alexandrocuma/gommit/pkg/ai"
 	"github.com/alexandrocuma/gommit/pkg/directory"
 	"github.com/manifoldco/promptui"
 )
 
-// Default prompt content embedded in code
-const (
-	defaultCommitPrompt = `# Commit Message Generator Prompt
-You are an expert Git commit message generator. Analyze the provided code diff and generate a concise, clear commit message following these rules:
-- Use present tense ("Add feature" not "Added feature")
-- Keep the first line under 50 characters
-- Provide a detailed description if the change is complex
-- Reference any related issues or tickets
-`
-
-	defaultDraftPrompt = `# PR Description Generator Prompt
-You are a skilled technical writer crafting pull request descriptions. Based on the code changes provided, create a comprehensive PR description that includes:
-- Clear summary of changes
-- Motivation and context
-- Breaking changes (if any)
-- Testing instructions
-- Screenshots for UI changes (mention if applicable)
-`
-
-	defaultReviewPrompt = `# Code Review Prompt
-You are an experienced senior software engineer conducting a thorough code review. Analyze the code changes and provide:
-- Potential bugs or errors
-- Security vulnerabilities
-- Performance issues
-- Code style improvements
-- Best practice recommendations
-- Specific line comments where relevant
-Be constructive and educational in your feedback.
-`
-)
-
 // RunPromptSetup runs the interactive configuration setup for prompt files
 func RunPromptSetup(config *config.Config) error {
 	// Ensure the directory exists
@@ -56,9 +26,9 @@ func RunPromptSetup(config *config.Config) error {
 		defaultValue string
 		label        string
 	}{
-		{"commit.md", defaultCommitPrompt, "💬 Configure 'commit generator' prompt"},
-		{"draft.md", defaultDraftPrompt, "💬 Configure 'PR description generator' prompt"},
-		{"review.md", defaultReviewPrompt, "💬 Configure 'PR reviewer' prompt"},
+		{"commit.md", ai.DefaultCommitPrompt, "💬 Configure 'commit generator' prompt"},
+		{"draft.md", ai.DefaultDraftPrompt, "💬 Configure 'PR description generator' prompt"},
+		{"review.md", ai.DefaultReviewPrompt, "💬 Configure 'PR reviewer' prompt"},
 	}
 
 	// Setup each prompt
@@ -118,4 +88,4 @@ func validateNonEmpty(input string) error {
 		return fmt.Errorf("prompt cannot be empty")
 	}
 	return nil
-}
\ No newline at end of file
+}
@@ -0,0 +1,33 @@
+package interactive
+
+import (
+	"fmt"
+
+	"github.com/manifoldco/promptui"
+)
+
+// TemplateChoice is a template offered by the template picker
+type TemplateChoice struct {
+	Path        string
+	Label       string
+	Description string
+}
+
+// SelectTemplate asks which of several templates to use and returns its path
+func SelectTemplate(choices []TemplateChoice) (string, error) {
+	templatePrompt := promptui.Select{
+		Label: "📝 Select a PR template",
+		Items: choices,
+		Templates: &promptui.SelectTemplates{
+			Active:   "▸ {{ .Label | cyan }}{{ if .Description }} — {{ .Description | faint }}{{ end }}",
+			Inactive: "  {{ .Label }}{{ if .Description }} — {{ .Description | faint }}{{ end }}",
+			Selected: "📝 Template: {{ .Label }}",
+		},
+	}
+
+	index, _, err := templatePrompt.Run()
+	if err != nil {
+		return "", fmt.Errorf("template selection failed: %w", err)
+	}
+	return choices[index].Path, nil
+}
@@ -0,0 +1,106 @@
+package markdown
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	east "github.com/yuin/goldmark/extension/ast"
+	"github.com/yuin/goldmark/text"
+)
+
+// converter renders GitHub flavored markdown, the dialect PR descriptions are written in
+var converter = goldmark.New(goldmark.WithExtensions(extension.GFM))
+
+// ToHTML converts a markdown document to an HTML fragment
+func ToHTML(document string) (string, error) {
+	var out bytes.Buffer
+	err := converter.Convert([]byte(document), &out)
+	if err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
+// PlainText converts a markdown document to plain text, keeping paragraphs,
+// list bullets and code blocks but dropping markup
+func PlainText(document string) string {
+	source := []byte(document)
+	root := converter.Parser().Parse(text.NewReader(source))
+
+	var out strings.Builder
+	_ = ast.Walk(root, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		switch n := node.(type) {
+		case *ast.Text:
+			if entering {
+				out.Write(n.Segment.Value(source))
+				if n.SoftLineBreak() || n.HardLineBreak() {
+					out.WriteString("\n")
+				}
+			}
+		case *ast.String:
+			if entering {
+				out.Write(n.Value)
+			}
+		case *ast.CodeBlock, *ast.FencedCodeBlock:
+			if entering {
+				if !strings.HasSuffix(out.String(), "\n\n") {
+					out.WriteString("\n")
+				}
+				lines := n.Lines()
+				for i := 0; i < lines.Len(); i++ {
+					segment := lines.At(i)
+					out.WriteString("    ")
+					out.Write(segment.Value(source))
+				}
+				out.WriteString("\n")
+				return ast.WalkSkipChildren, nil
+			}
+		case *ast.HTMLBlock, *ast.RawHTML:
+			return ast.WalkSkipChildren, nil
+		case *ast.ListItem:
+			if entering {
+				out.WriteString(strings.Repeat("  ", listDepth(n)) + "- ")
+			}
+		case *ast.Heading, *ast.Paragraph, *ast.TextBlock:
+			if !entering {
+				out.WriteString("\n")
+				if n.Kind() != ast.KindTextBlock {
+					out.WriteString("\n")
+				}
+			}
+		case *east.TableCell:
+			if entering && n.PreviousSibling() != nil {
+				out.WriteString(" | ")
+			}
+		case *east.TableHeader, *east.TableRow:
+			if !entering {
+				out.WriteString("\n")
+			}
+		case *east.Table:
+			if !entering {
+				out.WriteString("\n")
+			}
+		case *ast.ThematicBreak:
+			if entering {
+				out.WriteString("\n")
+			}
+		}
+		return ast.WalkContinue, nil
+	})
+
+	return blankLines.ReplaceAllString(strings.TrimSpace(out.String()), "\n\n") + "\n"
+}
+
+// listDepth returns how many lists a list item is nested in, minus one
+func listDepth(item ast.Node) int {
+	depth := -1
+	for parent := item.Parent(); parent != nil; parent = parent.Parent() {
+		if parent.Kind() == ast.KindList {
+			depth++
+		}
+	}
+	return depth
+}
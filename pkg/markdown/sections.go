@@ -0,0 +1,98 @@
+package markdown
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+var (
+	headingPattern = regexp.MustCompile(`^(#{1,6})\s+(.+?)\s*#*\s*$`)
+	fencePattern   = regexp.MustCompile("^\\s*(```|~~~)")
+)
+
+// Section is a heading and the text below it up to the next heading of any level.
+// Text before the first heading is returned as a section without heading.
+type Section struct {
+	Heading string `json:"heading"`
+	Level   int    `json:"level"`
+	Content string `json:"content"`
+}
+
+// Key returns the normalized heading used to match sections across documents
+func (s Section) Key() string {
+	return NormalizeHeading(s.Heading)
+}
+
+// Parse splits a markdown document into sections, ignoring headings inside code blocks
+func Parse(text string) []Section {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+
+	var sections []Section
+	current := Section{}
+	var lines []string
+	inFence := false
+
+	flush := func() {
+		current.Content = strings.Trim(strings.Join(lines, "\n"), "\n")
+		if current.Heading != "" || strings.TrimSpace(current.Content) != "" {
+			sections = append(sections, current)
+		}
+		lines = nil
+	}
+
+	for _, line := range strings.Split(text, "\n") {
+		if fencePattern.MatchString(line) {
+			inFence = !inFence
+		}
+		if !inFence {
+			if match := headingPattern.FindStringSubmatch(line); match != nil {
+				flush()
+				current = Section{Heading: match[2], Level: len(match[1])}
+				continue
+			}
+		}
+		lines = append(lines, line)
+	}
+	flush()
+
+	return sections
+}
+
+// Render joins sections back into a markdown document
+func Render(sections []Section) string {
+	parts := make([]string, 0, len(sections))
+	for _, section := range sections {
+		var part string
+		if section.Heading != "" {
+			part = strings.Repeat("#", section.Level) + " " + section.Heading
+			if section.Content != "" {
+				part += "\n\n" + section.Content
+			}
+		} else {
+			part = section.Content
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// NormalizeHeading lowercases a heading and strips emoji, punctuation and extra spaces
+// so "## 📋 Summary:" and "## Summary" match
+func NormalizeHeading(heading string) string {
+	var b strings.Builder
+	space := false
+	for _, r := range strings.ToLower(heading) {
+		switch {
+		case unicode.IsLetter(r), unicode.IsDigit(r):
+			if space && b.Len() > 0 {
+				b.WriteByte(' ')
+			}
+			b.WriteRune(r)
+			space = false
+		default:
+			space = true
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,172 @@
+package markdown
+
+import (
+	"regexp"
+	"strings"
+)
+
+// placeholderPattern matches template placeholder comments such as <!-- AI will list the changes here -->
+var placeholderPattern = regexp.MustCompile(`(?is)[ \t]*<!--\s*AI will.*?-->[ \t]*\n?`)
+
+// blankLines matches runs of empty lines left behind by removed placeholders
+var blankLines = regexp.MustCompile(`\n{3,}`)
+
+// StripPlaceholders removes leftover "<!-- AI will ... -->" template comments
+func StripPlaceholders(text string) string {
+	text = placeholderPattern.ReplaceAllString(text, "")
+	return strings.TrimSpace(blankLines.ReplaceAllString(text, "\n\n"))
+}
+
+// Headings returns the sections of a document that have a heading
+func Headings(sections []Section) []Section {
+	var headed []Section
+	for _, section := range sections {
+		if section.Heading != "" {
+			headed = append(headed, section)
+		}
+	}
+	return headed
+}
+
+// CompareSections reports which expected headings are missing from a document
+// and which headings of the document are not expected, matching normalized headings
+func CompareSections(expected, actual []Section) (missing, extra []Section) {
+	actual = foldSubsections(expected, actual)
+
+	available := make(map[string]int)
+	for _, section := range Headings(actual) {
+		available[section.Key()]++
+	}
+	wanted := make(map[string]int)
+	for _, section := range Headings(expected) {
+		wanted[section.Key()]++
+	}
+
+	for _, section := range Headings(expected) {
+		if available[section.Key()] > 0 {
+			available[section.Key()]--
+			continue
+		}
+		missing = append(missing, section)
+	}
+	for _, section := range Headings(actual) {
+		if wanted[section.Key()] > 0 {
+			wanted[section.Key()]--
+			continue
+		}
+		extra = append(extra, section)
+	}
+	return missing, extra
+}
+
+// Restructure rebuilds a document in the order and with the exact headings of
+// the expected sections. Content is taken from the document first and from
+// fill for sections the document lacks. Sections found in neither are left
+// with placeholder content. Unexpected sections of the document are folded
+// into the expected section before them so no generated content is lost.
+func Restructure(expected, actual, fill []Section, placeholder string) []Section {
+	actual = foldUnexpected(expected, foldSubsections(expected, actual))
+	fill = foldSubsections(expected, fill)
+
+	pool := make(map[string][]Section)
+	for _, source := range [][]Section{actual, fill} {
+		for _, section := range Headings(source) {
+			pool[section.Key()] = append(pool[section.Key()], section)
+		}
+	}
+
+	var result []Section
+	if len(actual) > 0 && actual[0].Heading == "" {
+		result = append(result, actual[0])
+	}
+
+	for _, section := range Headings(expected) {
+		out := Section{Heading: section.Heading, Level: section.Level, Content: placeholder}
+		if candidates := pool[section.Key()]; len(candidates) > 0 {
+			out.Content = candidates[0].Content
+			pool[section.Key()] = candidates[1:]
+		}
+		result = append(result, out)
+	}
+	return result
+}
+
+// foldSubsections moves unexpected headings nested below an expected section
+// into the content of that section, so "## Added" below "# Changelog" is kept
+func foldSubsections(expected, actual []Section) []Section {
+	wanted := make(map[string]bool)
+	for _, section := range Headings(expected) {
+		wanted[section.Key()] = true
+	}
+
+	var result []Section
+	parent := -1
+	for _, section := range actual {
+		if section.Heading != "" && !wanted[section.Key()] && parent != -1 && section.Level > result[parent].Level {
+			result[parent].Content = strings.TrimSpace(result[parent].Content + "\n\n" + Render([]Section{section}))
+			continue
+		}
+		result = append(result, section)
+		if section.Heading != "" && wanted[section.Key()] {
+			parent = len(result) - 1
+		} else {
+			parent = -1
+		}
+	}
+	return result
+}
+
+// foldUnexpected moves the remaining unexpected sections into the content of
+// the expected section before them, or of the first one when none precedes.
+// Their headings are kept as bold lines so the template structure is intact.
+func foldUnexpected(expected, actual []Section) []Section {
+	wanted := make(map[string]bool)
+	for _, section := range Headings(expected) {
+		wanted[section.Key()] = true
+	}
+
+	var result []Section
+	var pending []string
+	parent := -1
+	for _, section := range actual {
+		if section.Heading == "" || wanted[section.Key()] {
+			result = append(result, section)
+			if section.Heading != "" {
+				parent = len(result) - 1
+				if len(pending) > 0 {
+					result[parent].Content = joinContent(append(pending, result[parent].Content)...)
+					pending = nil
+				}
+			}
+			continue
+		}
+
+		folded := joinContent("**"+section.Heading+"**", section.Content)
+		if parent == -1 {
+			pending = append(pending, folded)
+			continue
+		}
+		result[parent].Content = joinContent(result[parent].Content, folded)
+	}
+
+	// Without any expected section the content is kept as the introduction
+	if len(pending) > 0 {
+		if len(result) > 0 && result[0].Heading == "" {
+			result[0].Content = joinContent(result[0].Content, joinContent(pending...))
+		} else {
+			result = append([]Section{{Content: joinContent(pending...)}}, result...)
+		}
+	}
+	return result
+}
+
+// joinContent joins non-empty blocks of content with a blank line
+func joinContent(blocks ...string) string {
+	var parts []string
+	for _, block := range blocks {
+		if block = strings.TrimSpace(block); block != "" {
+			parts = append(parts, block)
+		}
+	}
+	return strings.Join(parts, "\n\n")
+}
@@ -0,0 +1,130 @@
+package markdown
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+// KeepMarker marks a section that gommit must never regenerate
+const KeepMarker = "<!-- gommit:keep -->"
+
+var statePattern = regexp.MustCompile(`(?m)^<!-- gommit:state (\{.*\}) -->\s*$`)
+
+// State is stored as a hidden comment at the end of a generated description.
+// It records the head commit the description was generated for and a hash of
+// every generated section, so later updates can tell which sections were edited by hand.
+type State struct {
+	Head     string            `json:"head"`
+	Sections map[string]string `json:"sections"`
+}
+
+// ReadState extracts the gommit state from a description. It reports false
+// when the description was not generated by gommit.
+func ReadState(body string) (State, bool) {
+	match := statePattern.FindStringSubmatch(strings.ReplaceAll(body, "\r\n", "\n"))
+	if match == nil {
+		return State{}, false
+	}
+
+	var state State
+	if json.Unmarshal([]byte(match[1]), &state) != nil {
+		return State{}, false
+	}
+	return state, true
+}
+
+// StripState removes the gommit state comment from a description
+func StripState(body string) string {
+	body = strings.ReplaceAll(body, "\r\n", "\n")
+	return strings.TrimSpace(statePattern.ReplaceAllString(body, ""))
+}
+
+// Stamp appends the gommit state for the head commit to a description,
+// replacing any previous state. Kept headings are left out of the state so
+// they stay preserved on later updates.
+func Stamp(body, head string, kept []string) string {
+	body = StripState(body)
+
+	skip := make(map[string]bool)
+	for _, heading := range kept {
+		skip[NormalizeHeading(heading)] = true
+	}
+
+	state := State{Head: head, Sections: make(map[string]string)}
+	for _, section := range Parse(body) {
+		if !skip[section.Key()] {
+			state.Sections[section.Key()] = contentHash(section.Content)
+		}
+	}
+
+	encoded, err := json.Marshal(state)
+	if err != nil {
+		return body
+	}
+	return body + "\n\n<!-- gommit:state " + string(encoded) + " -->"
+}
+
+// Merge updates an existing description with a newly generated one. Sections
+// that carry the keep marker, were edited after gommit wrote them or do not
+// exist in the generated description are kept as they are; all other sections
+// are replaced. New sections of the generated description are appended. It
+// returns the merged description and the headings of the kept sections.
+func Merge(existing, generated string) (string, []string) {
+	state, stamped := ReadState(existing)
+	current := Parse(StripState(existing))
+	fresh := Parse(StripState(generated))
+
+	freshByKey := make(map[string]Section)
+	for _, section := range fresh {
+		freshByKey[section.Key()] = section
+	}
+
+	var merged []Section
+	var kept []string
+	used := make(map[string]bool)
+	for _, section := range current {
+		key := section.Key()
+		replacement, ok := freshByKey[key]
+		used[key] = true
+
+		if !ok || isHumanEdited(section, state, stamped) {
+			merged = append(merged, section)
+			if ok && section.Heading != "" {
+				kept = append(kept, section.Heading)
+			}
+			continue
+		}
+		merged = append(merged, replacement)
+	}
+
+	for _, section := range fresh {
+		if !used[section.Key()] {
+			merged = append(merged, section)
+		}
+	}
+
+	return Render(merged), kept
+}
+
+// isHumanEdited reports whether a section must be preserved on update
+func isHumanEdited(section Section, state State, stamped bool) bool {
+	if strings.Contains(section.Content, KeepMarker) {
+		return true
+	}
+	if !stamped {
+		// Descriptions not written by gommit only keep marked sections
+		return false
+	}
+
+	hash, ok := state.Sections[section.Key()]
+	return !ok || hash != contentHash(section.Content)
+}
+
+// contentHash returns a short hash of section content, ignoring surrounding whitespace
+func contentHash(content string) string {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(content)))
+	return hex.EncodeToString(sum[:6])
+}
@@ -0,0 +1,93 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/alexandrocuma/gommit/pkg/ai"
+	"github.com/alexandrocuma/gommit/pkg/markdown"
+	"github.com/alexandrocuma/gommit/pkg/review"
+)
+
+// Output formats
+const (
+	FormatMarkdown = "markdown"
+	FormatJSON     = "json"
+	FormatHTML     = "html"
+	FormatPlain    = "plain"
+	FormatSARIF    = "sarif" // Review findings only
+)
+
+var (
+	// Formats lists the output formats of any document
+	Formats = []string{FormatMarkdown, FormatJSON, FormatHTML, FormatPlain}
+
+	// ReviewFormats lists the output formats of a review
+	ReviewFormats = []string{FormatMarkdown, FormatJSON, FormatHTML, FormatPlain, FormatSARIF}
+)
+
+// Document is generated content together with the data about how it was generated
+type Document struct {
+	Title    string             `json:"title,omitempty"`
+	Body     string             `json:"body"`
+	Sections []markdown.Section `json:"sections"`
+	Model    string             `json:"model"`
+	Provider string             `json:"provider"`
+	Usage    ai.Usage           `json:"usage"`
+	Review   *review.Report     `json:"review,omitempty"` // Structured findings of a review
+}
+
+// NewDocument creates a document from a markdown body, the model that wrote it
+// and the client that generated it
+func NewDocument(title, body, model string, client *ai.Client) *Document {
+	sections := markdown.Headings(markdown.Parse(body))
+	if sections == nil {
+		sections = []markdown.Section{}
+	}
+	return &Document{
+		Title:    title,
+		Body:     body,
+		Sections: sections,
+		Model:    model,
+		Provider: client.Provider(),
+		Usage:    client.Usage(),
+	}
+}
+
+// Markdown returns the document as markdown, with the title as top heading
+func (d *Document) Markdown() string {
+	if d.Title == "" {
+		return strings.TrimSpace(d.Body) + "\n"
+	}
+	return fmt.Sprintf("# %s\n\n%s\n", d.Title, strings.TrimSpace(d.Body))
+}
+
+// Render formats the document
+func Render(doc *Document, format string) (string, error) {
+	switch format {
+	case FormatMarkdown:
+		return doc.Markdown(), nil
+	case FormatJSON:
+		content, err := json.MarshalIndent(doc, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("error encoding JSON output: %w", err)
+		}
+		return string(content) + "\n", nil
+	case FormatHTML:
+		html, err := markdown.ToHTML(doc.Markdown())
+		if err != nil {
+			return "", fmt.Errorf("error rendering HTML output: %w", err)
+		}
+		return html, nil
+	case FormatPlain:
+		return markdown.PlainText(doc.Markdown()), nil
+	case FormatSARIF:
+		if doc.Review == nil {
+			return "", fmt.Errorf("the %s format is only available for reviews", FormatSARIF)
+		}
+		return doc.Review.SARIF()
+	default:
+		return "", fmt.Errorf("unsupported output format %q, use one of: %s", format, strings.Join(Formats, ", "))
+	}
+}
@@ -0,0 +1,180 @@
+package patch
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/alexandrocuma/gommit/internal/git"
+)
+
+// hunkHeader matches "@@ -1,5 +1,6 @@", the counts default to 1 when omitted
+var hunkHeader = regexp.MustCompile(`^@@ -\d+(?:,(\d+))? \+(\d+)(?:,(\d+))? @@`)
+
+// FileChanges returns the files changed by a diff, with the same details git
+// reports for a revision range: status, renames, modes, line counts and binaries
+func FileChanges(diff string) []git.FileChange {
+	var changes []git.FileChange
+	var current *git.FileChange
+	hasHunks := false
+	oldLeft, newLeft := 0, 0
+
+	for _, line := range strings.Split(diff, "\n") {
+		// Inside a hunk, count lines until both sides are consumed
+		if oldLeft > 0 || newLeft > 0 {
+			switch {
+			case strings.HasPrefix(line, "+"):
+				current.Added++
+				newLeft--
+			case strings.HasPrefix(line, "-"):
+				current.Deleted++
+				oldLeft--
+			case strings.HasPrefix(line, `\`):
+				// "\ No newline at end of file"
+			default:
+				oldLeft--
+				newLeft--
+			}
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			changes = append(changes, git.FileChange{Status: 'M', Path: gitDiffPath(line)})
+			current = &changes[len(changes)-1]
+			hasHunks = false
+		case strings.HasPrefix(line, "--- ") && (current == nil || hasHunks):
+			// Plain diff without git headers
+			changes = append(changes, git.FileChange{Status: 'M', Path: diffHeaderPath(line)})
+			current = &changes[len(changes)-1]
+			hasHunks = false
+			if current.Path == "" {
+				current.Status = 'A'
+			}
+		case current == nil:
+			continue
+		case hunkHeader.MatchString(line):
+			m := hunkHeader.FindStringSubmatch(line)
+			oldLeft, newLeft = hunkCount(m[1]), hunkCount(m[3])
+			hasHunks = true
+		case strings.HasPrefix(line, "--- "):
+			if diffHeaderPath(line) == "" {
+				current.Status = 'A'
+			}
+		case strings.HasPrefix(line, "+++ "):
+			if path := diffHeaderPath(line); path != "" {
+				current.Path = path
+			} else {
+				current.Status = 'D'
+			}
+		case strings.HasPrefix(line, "new file mode "):
+			current.Status = 'A'
+			current.NewMode = strings.TrimPrefix(line, "new file mode ")
+		case strings.HasPrefix(line, "deleted file mode "):
+			current.Status = 'D'
+			current.OldMode = strings.TrimPrefix(line, "deleted file mode ")
+		case strings.HasPrefix(line, "old mode "):
+			current.OldMode = strings.TrimPrefix(line, "old mode ")
+		case strings.HasPrefix(line, "new mode "):
+			current.NewMode = strings.TrimPrefix(line, "new mode ")
+		case strings.HasPrefix(line, "rename from "):
+			current.Status = 'R'
+			current.OldPath = strings.TrimPrefix(line, "rename from ")
+		case strings.HasPrefix(line, "copy from "):
+			current.Status = 'C'
+			current.OldPath = strings.TrimPrefix(line, "copy from ")
+		case strings.HasPrefix(line, "rename to "):
+			current.Path = strings.TrimPrefix(line, "rename to ")
+		case strings.HasPrefix(line, "copy to "):
+			current.Path = strings.TrimPrefix(line, "copy to ")
+		case strings.HasPrefix(line, "similarity index "):
+			current.Similarity, _ = strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(line, "similarity index "), "%"))
+		case strings.HasPrefix(line, "Binary files "), line == "GIT binary patch":
+			current.Binary = true
+		}
+	}
+	return mergeChanges(changes)
+}
+
+// mergeChanges folds the changes of a file touched by several patches of a series
+func mergeChanges(changes []git.FileChange) []git.FileChange {
+	var merged []git.FileChange
+	index := make(map[string]int)
+	for _, change := range changes {
+		i, ok := index[change.Path]
+		if !ok {
+			index[change.Path] = len(merged)
+			merged = append(merged, change)
+			continue
+		}
+		merged[i].Added += change.Added
+		merged[i].Deleted += change.Deleted
+		merged[i].Binary = merged[i].Binary || change.Binary
+		if change.Status == 'D' {
+			merged[i].Status = 'D'
+		}
+	}
+	return merged
+}
+
+// hunkCount parses the line count of a hunk header, which is 1 when omitted
+func hunkCount(count string) int {
+	if count == "" {
+		return 1
+	}
+	n, _ := strconv.Atoi(count)
+	return n
+}
+
+// Stat summarizes file changes like git diff --stat
+func Stat(changes []git.FileChange) string {
+	var b strings.Builder
+	added, deleted := 0, 0
+	for _, change := range changes {
+		path := change.Path
+		if change.OldPath != "" {
+			path = fmt.Sprintf("%s => %s", change.OldPath, change.Path)
+		}
+		if change.Binary {
+			fmt.Fprintf(&b, " %s | Bin\n", path)
+			continue
+		}
+		graph := strings.Repeat("+", min(change.Added, 40)) + strings.Repeat("-", min(change.Deleted, 40))
+		fmt.Fprintf(&b, " %s | %s\n", path, strings.TrimSpace(fmt.Sprintf("%d %s", change.Added+change.Deleted, graph)))
+		added += change.Added
+		deleted += change.Deleted
+	}
+
+	files := "files"
+	if len(changes) == 1 {
+		files = "file"
+	}
+	fmt.Fprintf(&b, " %d %s changed, %d insertions(+), %d deletions(-)\n", len(changes), files, added, deleted)
+	return b.String()
+}
+
+// gitDiffPath returns the new path of a "diff --git a/path b/path" line
+func gitDiffPath(line string) string {
+	paths := strings.TrimPrefix(line, "diff --git ")
+	if i := strings.LastIndex(paths, " b/"); i >= 0 {
+		return paths[i+len(" b/"):]
+	}
+	return paths
+}
+
+// diffHeaderPath returns the path of a "--- a/path" or "+++ b/path" line,
+// or an empty string for /dev/null
+func diffHeaderPath(line string) string {
+	path := line[len("--- "):]
+	if i := strings.IndexByte(path, '\t'); i >= 0 {
+		path = path[:i]
+	}
+	if path == "/dev/null" {
+		return ""
+	}
+	if strings.HasPrefix(path, "a/") || strings.HasPrefix(path, "b/") {
+		return path[2:]
+	}
+	return path
+}
@@ -0,0 +1,72 @@
+package patch
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Line is a line on the new side of a diff hunk
+type Line struct {
+	Number int
+	Text   string
+	Added  bool // Added or changed, false for context lines
+}
+
+// NewLines returns the lines on the new side of the hunks of a diff, keyed by
+// file path and ordered by line number. Deleted files have no entry.
+func NewLines(diff string) map[string][]Line {
+	files := make(map[string][]Line)
+	var path string
+	number, oldLeft, newLeft := 0, 0, 0
+
+	for _, line := range strings.Split(diff, "\n") {
+		if oldLeft > 0 || newLeft > 0 {
+			switch {
+			case strings.HasPrefix(line, "+"):
+				files[path] = append(files[path], Line{Number: number, Text: line[1:], Added: true})
+				number++
+				newLeft--
+			case strings.HasPrefix(line, "-"):
+				oldLeft--
+			case strings.HasPrefix(line, `\`):
+				// "\ No newline at end of file"
+			default:
+				if line != "" {
+					line = line[1:]
+				}
+				files[path] = append(files[path], Line{Number: number, Text: line})
+				number++
+				oldLeft--
+				newLeft--
+			}
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(line, "+++ "):
+			path = diffHeaderPath(line)
+		case path != "" && hunkHeader.MatchString(line):
+			m := hunkHeader.FindStringSubmatch(line)
+			number, _ = strconv.Atoi(m[2])
+			oldLeft, newLeft = hunkCount(m[1]), hunkCount(m[3])
+		}
+	}
+
+	// The patches of a series may touch a file more than once, the later patch
+	// has the final text and a line stays changed if any patch changed it
+	for path, lines := range files {
+		sort.SliceStable(lines, func(i, j int) bool { return lines[i].Number < lines[j].Number })
+		merged := lines[:0]
+		for _, line := range lines {
+			if n := len(merged); n > 0 && merged[n-1].Number == line.Number {
+				line.Added = line.Added || merged[n-1].Added
+				merged[n-1] = line
+				continue
+			}
+			merged = append(merged, line)
+		}
+		files[path] = merged
+	}
+	return files
+}
@@ -0,0 +1,234 @@
+package patch
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net/mail"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/alexandrocuma/gommit/internal/git"
+)
+
+// Stdin is the path that reads the patch from standard input
+const Stdin = "-"
+
+var (
+	// mboxSeparator starts a message in an mbox, e.g. "From 1a2b... Mon Sep 17 00:00:00 2001"
+	mboxSeparator = regexp.MustCompile(`(?m)^From (\S+) +(Mon|Tue|Wed|Thu|Fri|Sat|Sun) `)
+	// mailHeader matches the first line of a single email saved without mbox separator
+	mailHeader = regexp.MustCompile(`^(From|Subject|Date|Message-I[dD]|Return-Path): `)
+	// subjectTag matches the "[PATCH v2 3/7]" tags in front of patch subjects
+	subjectTag = regexp.MustCompile(`^\s*\[[^\]]*\]\s*`)
+	// seriesIndex matches the 0/N index of a cover letter in the subject tags
+	seriesIndex = regexp.MustCompile(`\b0+/\d+\]`)
+	// coverPlaceholder is the subject of a cover letter that was not filled in
+	coverPlaceholder = "*** SUBJECT HERE ***"
+	fullHash         = regexp.MustCompile(`^[0-9a-f]{40}$`)
+)
+
+// Patch is a diff read from a file or stdin, with the commits it describes
+// when it was produced by git format-patch
+type Patch struct {
+	Diff    string
+	Commits []git.Commit // Newest first, like git log
+	Cover   *git.Commit  // Cover letter of a patch series
+}
+
+// Read reads a patch from a file, or from stdin when path is "-"
+func Read(path string) (*Patch, error) {
+	var content []byte
+	var err error
+	if path == Stdin {
+		content, err = io.ReadAll(os.Stdin)
+	} else {
+		content, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading patch %q: %w", path, err)
+	}
+
+	p := Parse(string(content))
+	if strings.TrimSpace(p.Diff) == "" {
+		return nil, fmt.Errorf("%w in patch %q", git.ErrNoChanges, path)
+	}
+	return p, nil
+}
+
+// Parse parses a plain diff or a git format-patch mbox with one or more patches
+func Parse(content string) *Patch {
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+
+	messages := splitMbox(content)
+	if messages == nil {
+		return &Patch{Diff: content}
+	}
+
+	p := &Patch{}
+	var diffs []string
+	for _, message := range messages {
+		commit, diff, ok := parseMessage(message)
+		if !ok {
+			continue
+		}
+		if diff == "" {
+			if p.Cover == nil && seriesIndex.MatchString(message) && commit.Subject != coverPlaceholder {
+				p.Cover = &commit
+			}
+			continue
+		}
+		diffs = append(diffs, diff)
+		p.Commits = append([]git.Commit{commit}, p.Commits...)
+	}
+	p.Diff = strings.Join(diffs, "")
+	return p
+}
+
+// Title returns a title for the change: the cover letter subject of a series,
+// the subject of a single patch, or an empty string
+func (p *Patch) Title() string {
+	switch {
+	case p.Cover != nil:
+		return p.Cover.Subject
+	case len(p.Commits) == 1:
+		return p.Commits[0].Subject
+	default:
+		return ""
+	}
+}
+
+// splitMbox splits an mbox into messages, keeping the separator line. It
+// returns nil when the content is not an email but a plain diff.
+func splitMbox(content string) []string {
+	locs := mboxSeparator.FindAllStringIndex(content, -1)
+	if len(locs) == 0 {
+		if mailHeader.MatchString(content) {
+			return []string{content}
+		}
+		return nil
+	}
+
+	messages := make([]string, len(locs))
+	for i, loc := range locs {
+		end := len(content)
+		if i+1 < len(locs) {
+			end = locs[i+1][0]
+		}
+		messages[i] = content[loc[0]:end]
+	}
+	return messages
+}
+
+// parseMessage extracts the commit metadata and the diff from a format-patch email
+func parseMessage(message string) (git.Commit, string, bool) {
+	var commit git.Commit
+	if m := mboxSeparator.FindStringSubmatch(message); m != nil {
+		if fullHash.MatchString(m[1]) {
+			commit.Hash = m[1]
+		}
+		_, message, _ = strings.Cut(message, "\n")
+	}
+
+	msg, err := mail.ReadMessage(strings.NewReader(message))
+	if err != nil {
+		return commit, "", false
+	}
+	body, err := decodeBody(msg)
+	if err != nil {
+		return commit, "", false
+	}
+
+	decoder := new(mime.WordDecoder)
+	subject, err := decoder.DecodeHeader(msg.Header.Get("Subject"))
+	if err != nil {
+		subject = msg.Header.Get("Subject")
+	}
+	for subjectTag.MatchString(subject) {
+		subject = subjectTag.ReplaceAllString(subject, "")
+	}
+	commit.Subject = strings.TrimSpace(subject)
+	commit.Author = formatAddress(msg.Header.Get("From"))
+	if date, err := msg.Header.Date(); err == nil {
+		commit.Date = date
+	}
+
+	// A different author is given as the first line of the body
+	if from, rest, ok := strings.Cut(body, "\n"); ok && strings.HasPrefix(from, "From: ") {
+		commit.Author = formatAddress(strings.TrimPrefix(from, "From: "))
+		body = strings.TrimLeft(rest, "\n")
+	}
+
+	message, diff := splitBody(body)
+	commit.Body = message
+	return commit, diff, true
+}
+
+// decodeBody returns the message body, decoding quoted-printable and base64 transfer encodings
+func decodeBody(msg *mail.Message) (string, error) {
+	var reader io.Reader = msg.Body
+	switch strings.ToLower(msg.Header.Get("Content-Transfer-Encoding")) {
+	case "quoted-printable":
+		reader = quotedprintable.NewReader(reader)
+	case "base64":
+		reader = base64.NewDecoder(base64.StdEncoding, reader)
+	}
+
+	var body bytes.Buffer
+	_, err := io.Copy(&body, reader)
+	return body.String(), err
+}
+
+// splitBody splits a patch email body into the commit message and the diff,
+// dropping the diffstat and the trailing git version signature
+func splitBody(body string) (string, string) {
+	start := diffStart(body)
+	if start < 0 {
+		return strings.TrimSpace(body), ""
+	}
+
+	message := body[:start]
+	if i := strings.Index(message, "\n---\n"); i >= 0 {
+		message = message[:i]
+	} else if strings.HasPrefix(message, "---\n") {
+		message = ""
+	}
+
+	diff := body[start:]
+	if i := strings.LastIndex(diff, "\n-- \n"); i >= 0 {
+		diff = diff[:i+1]
+	}
+	return strings.TrimSpace(message), diff
+}
+
+// diffStart returns the offset of the first diff header, or -1 when there is no diff
+func diffStart(body string) int {
+	for _, marker := range []string{"diff --git ", "Index: ", "--- "} {
+		if strings.HasPrefix(body, marker) {
+			return 0
+		}
+		if i := strings.Index(body, "\n"+marker); i >= 0 {
+			if marker == "--- " && !strings.Contains(body[i+1:], "\n+++ ") {
+				continue
+			}
+			return i + 1
+		}
+	}
+	return -1
+}
+
+// formatAddress returns the decoded "Name <email>" form of an address
+func formatAddress(address string) string {
+	parsed, err := mail.ParseAddress(address)
+	if err != nil {
+		return strings.TrimSpace(address)
+	}
+	if parsed.Name == "" {
+		return parsed.Address
+	}
+	return fmt.Sprintf("%s <%s>", parsed.Name, parsed.Address)
+}
@@ -0,0 +1,158 @@
+package review
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/alexandrocuma/gommit/pkg/patch"
+)
+
+const (
+	// snapDistance is how many lines a cited line may be away from a changed
+	// line to be snapped to it, further away the finding is unanchored
+	snapDistance = 10
+	// snippetLines limits the code shown next to a finding
+	snippetLines = 8
+)
+
+// Anchor maps every finding to changed lines on the new side of the diff.
+// A line range that covers changed lines is narrowed to them, one that
+// misses them is snapped to the nearest changed line, and findings without
+// a changed line nearby are marked unanchored. Ranges are cut at the end of
+// the hunk they start in, as review comments cannot span several hunks.
+// Anchored findings get the code snippet of their lines. It returns the
+// number of unanchored findings.
+func (r *Report) Anchor(lines map[string][]patch.Line) int {
+	unanchored := 0
+	for i := range r.Findings {
+		finding := &r.Findings[i]
+		finding.Anchored = false
+		finding.Snippet = ""
+
+		path, ok := matchPath(lines, finding.File)
+		if !ok || finding.StartLine == 0 {
+			unanchored++
+			continue
+		}
+		finding.File = path
+
+		candidates := changedLines(lines[path])
+		start, end, ok := snap(candidates, finding.StartLine, finding.EndLine)
+		if !ok {
+			unanchored++
+			continue
+		}
+		end = clampToHunk(lines[path], candidates, start, end)
+
+		finding.StartLine, finding.EndLine = start, end
+		finding.Anchored = true
+		finding.Snippet = snippet(lines[path], start, end)
+	}
+	return unanchored
+}
+
+// matchPath finds the diff path a finding refers to, accepting paths with a
+// leading "./" and unambiguous path suffixes
+func matchPath(lines map[string][]patch.Line, file string) (string, bool) {
+	file = strings.TrimPrefix(file, "./")
+	if _, ok := lines[file]; ok {
+		return file, true
+	}
+
+	var match string
+	for path := range lines {
+		if strings.HasSuffix(path, "/"+file) {
+			if match != "" {
+				return "", false
+			}
+			match = path
+		}
+	}
+	return match, match != ""
+}
+
+// changedLines returns the added lines of a file, or all hunk lines when the
+// file only has deletions so findings can point next to them
+func changedLines(lines []patch.Line) []int {
+	var added, all []int
+	for _, line := range lines {
+		all = append(all, line.Number)
+		if line.Added {
+			added = append(added, line.Number)
+		}
+	}
+	if len(added) > 0 {
+		return added
+	}
+	return all
+}
+
+// snap narrows a line range to the changed lines it covers, or moves it to the nearest changed line
+func snap(candidates []int, start, end int) (int, int, bool) {
+	first, last := 0, 0
+	for _, n := range candidates {
+		if n >= start && n <= end {
+			if first == 0 {
+				first = n
+			}
+			last = n
+		}
+	}
+	if first != 0 {
+		return first, last, true
+	}
+
+	nearest, best := 0, snapDistance+1
+	for _, n := range candidates {
+		distance := max(start-n, n-end)
+		if distance < best {
+			nearest, best = n, distance
+		}
+	}
+	if nearest == 0 {
+		return 0, 0, false
+	}
+	return nearest, nearest, true
+}
+
+// clampToHunk moves the end of a range back to the last changed line of the
+// hunk the range starts in. Lines of a hunk have consecutive numbers, git
+// merges hunks that would touch.
+func clampToHunk(lines []patch.Line, candidates []int, start, end int) int {
+	hunkEnd := start
+	for _, line := range lines {
+		if line.Number == hunkEnd+1 {
+			hunkEnd = line.Number
+		}
+	}
+
+	clamped := start
+	for _, n := range candidates {
+		if n > clamped && n <= min(end, hunkEnd) {
+			clamped = n
+		}
+	}
+	return clamped
+}
+
+// snippet returns the diff lines of a range, marking added lines with "+"
+func snippet(lines []patch.Line, start, end int) string {
+	var b strings.Builder
+	count := 0
+	for _, line := range lines {
+		if line.Number < start || line.Number > end {
+			continue
+		}
+		if count == snippetLines {
+			b.WriteString("…\n")
+			break
+		}
+		marker := " "
+		if line.Added {
+			marker = "+"
+		}
+		fmt.Fprintf(&b, "%s%s\n", marker, line.Text)
+		count++
+	}
+	return b.String()
+}
@@ -0,0 +1,113 @@
+package review
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// ErrGate is returned when a report does not pass the failure threshold of a
+// pipeline, so callers can exit with a dedicated code
+var ErrGate = errors.New("review failed")
+
+// Filter keeps the findings of the given categories, all of them when empty
+func (r *Report) Filter(categories []string) {
+	if len(categories) == 0 {
+		return
+	}
+
+	kept := []Finding{}
+	for _, finding := range r.Findings {
+		if contains(categories, finding.Category) {
+			kept = append(kept, finding)
+		}
+	}
+	r.Findings = kept
+}
+
+// Gate checks the report against a failure threshold: no finding may be at
+// or above failOn and there may be at most maxFindings findings. An empty
+// failOn or a maxFindings of 0 disables the respective check.
+func (r *Report) Gate(failOn string, maxFindings int) error {
+	var reasons []string
+
+	if threshold := SeverityRank(failOn); threshold >= 0 {
+		failing := 0
+		for _, finding := range r.Findings {
+			if rank := SeverityRank(finding.Severity); rank >= 0 && rank <= threshold {
+				failing++
+			}
+		}
+		if failing > 0 {
+			reasons = append(reasons, fmt.Sprintf("%d %s at or above %s", failing, plural(failing, "finding"), failOn))
+		}
+	}
+
+	if maxFindings > 0 && len(r.Findings) > maxFindings {
+		reasons = append(reasons, fmt.Sprintf("%d %s, more than the maximum of %d", len(r.Findings), plural(len(r.Findings), "finding"), maxFindings))
+	}
+
+	if len(reasons) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrGate, strings.Join(reasons, " and "))
+}
+
+// SummaryTable returns the number of findings by severity and category as a
+// compact text table, empty rows are left out
+func (r *Report) SummaryTable() string {
+	counts := map[string]map[string]int{}
+	for _, finding := range r.Findings {
+		if counts[finding.Severity] == nil {
+			counts[finding.Severity] = map[string]int{}
+		}
+		counts[finding.Severity][finding.Category]++
+	}
+
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintf(w, "severity\t%s\ttotal\t\n", strings.Join(Categories, "\t"))
+
+	totals := map[string]int{}
+	for _, severity := range Severities {
+		if len(counts[severity]) == 0 {
+			continue
+		}
+
+		row := []string{severity}
+		total := 0
+		for _, category := range Categories {
+			count := counts[severity][category]
+			row = append(row, countCell(count))
+			totals[category] += count
+			total += count
+		}
+		fmt.Fprintf(w, "%s\t%d\t\n", strings.Join(row, "\t"), total)
+	}
+
+	row := []string{"total"}
+	for _, category := range Categories {
+		row = append(row, strconv.Itoa(totals[category]))
+	}
+	fmt.Fprintf(w, "%s\t%d\t\n", strings.Join(row, "\t"), len(r.Findings))
+
+	w.Flush()
+	return b.String()
+}
+
+// countCell shows zero counts as a dot so the table is easy to scan
+func countCell(count int) string {
+	if count == 0 {
+		return "·"
+	}
+	return strconv.Itoa(count)
+}
+
+func plural(count int, word string) string {
+	if count == 1 {
+		return word
+	}
+	return word + "s"
+}
@@ -0,0 +1,86 @@
+package review
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// severityIcons marks the severity groups of the report
+var severityIcons = map[string]string{
+	SeverityCritical: "🔴",
+	SeverityHigh:     "🟠",
+	SeverityMedium:   "🟡",
+	SeverityLow:      "🔵",
+	SeverityInfo:     "⚪",
+}
+
+// Markdown renders the report grouped by severity, most severe first, with
+// the findings of each group ordered by file and line
+func (r *Report) Markdown() string {
+	var b strings.Builder
+	if r.Summary != "" {
+		fmt.Fprintf(&b, "## Summary\n\n%s\n", strings.TrimSpace(r.Summary))
+	}
+	if len(r.Findings) == 0 {
+		b.WriteString("\nNo issues found.\n")
+		return strings.TrimLeft(b.String(), "\n")
+	}
+
+	for _, severity := range Severities {
+		findings := r.bySeverity(severity)
+		if len(findings) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(&b, "\n## %s %s (%d)\n", severityIcons[severity], strings.ToUpper(severity[:1])+severity[1:], len(findings))
+		for _, finding := range findings {
+			unanchored := ""
+			if !finding.Anchored {
+				unanchored = " · ⚠️ unanchored"
+			}
+			fmt.Fprintf(&b, "\n### `%s` · %s%s\n", finding.Location(), finding.Category, unanchored)
+			if finding.Snippet != "" {
+				fmt.Fprintf(&b, "\n```diff\n%s```\n", finding.Snippet)
+			}
+			fmt.Fprintf(&b, "\n%s\n", finding.Explanation)
+			switch {
+			case strings.Contains(finding.Fix, "\n"):
+				fmt.Fprintf(&b, "\n**Suggested fix:**\n\n%s\n", finding.Fix)
+			case finding.Fix != "":
+				fmt.Fprintf(&b, "\n**Suggested fix:** %s\n", finding.Fix)
+			}
+		}
+	}
+	return strings.TrimLeft(b.String(), "\n")
+}
+
+// bySeverity returns the findings of a severity ordered by file and line
+func (r *Report) bySeverity(severity string) []Finding {
+	var findings []Finding
+	for _, finding := range r.Findings {
+		if finding.Severity == severity {
+			findings = append(findings, finding)
+		}
+	}
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].File != findings[j].File {
+			return findings[i].File < findings[j].File
+		}
+		return findings[i].StartLine < findings[j].StartLine
+	})
+	return findings
+}
+
+// Comment renders a finding as an inline review comment
+func (f Finding) Comment() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s **%s** · %s\n\n%s\n", severityIcons[f.Severity], strings.ToUpper(f.Severity[:1])+f.Severity[1:], f.Category, f.Explanation)
+	switch {
+	case strings.Contains(f.Fix, "\n"):
+		fmt.Fprintf(&b, "\n**Suggested fix:**\n\n%s\n", f.Fix)
+	case f.Fix != "":
+		fmt.Fprintf(&b, "\n**Suggested fix:** %s\n", f.Fix)
+	}
+	return b.String()
+}
@@ -0,0 +1,198 @@
+package review
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Severities from most to least severe
+const (
+	SeverityCritical = "critical"
+	SeverityHigh     = "high"
+	SeverityMedium   = "medium"
+	SeverityLow      = "low"
+	SeverityInfo     = "info"
+)
+
+// Severities lists the severities from most to least severe
+var Severities = []string{SeverityCritical, SeverityHigh, SeverityMedium, SeverityLow, SeverityInfo}
+
+// Categories of findings
+const (
+	CategoryBug         = "bug"
+	CategorySecurity    = "security"
+	CategoryPerformance = "performance"
+	CategoryStyle       = "style"
+	CategoryTest        = "test"
+)
+
+// Categories lists the finding categories
+var Categories = []string{CategoryBug, CategorySecurity, CategoryPerformance, CategoryStyle, CategoryTest}
+
+// aliases maps common model spellings to the canonical severity or category
+var aliases = map[string]string{
+	"blocker":  SeverityCritical,
+	"error":    SeverityHigh,
+	"major":    SeverityHigh,
+	"warning":  SeverityMedium,
+	"moderate": SeverityMedium,
+	"minor":    SeverityLow,
+	"nit":      SeverityLow,
+	"note":     SeverityInfo,
+	"bugs":     CategoryBug,
+	"perf":     CategoryPerformance,
+	"tests":    CategoryTest,
+	"testing":  CategoryTest,
+}
+
+// Finding is a single issue found in the reviewed changes
+type Finding struct {
+	File        string `json:"file"`
+	StartLine   int    `json:"start_line"`
+	EndLine     int    `json:"end_line"`
+	Severity    string `json:"severity"`
+	Category    string `json:"category"`
+	Explanation string `json:"explanation"`
+	Fix         string `json:"fix,omitempty"` // Suggested fix
+	Anchored    bool   `json:"anchored"`      // The lines were matched to changed lines of the diff
+	Snippet     string `json:"snippet,omitempty"`
+}
+
+// Report is the structured result of a review
+type Report struct {
+	Summary  string    `json:"summary"`
+	Findings []Finding `json:"findings"`
+}
+
+// Parse decodes a report from a model response. The JSON object may be
+// wrapped in a code fence or surrounded by text.
+func Parse(content string) (*Report, error) {
+	start := strings.Index(content, "{")
+	end := strings.LastIndex(content, "}")
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("review response is not a JSON object")
+	}
+
+	var report Report
+	err := json.Unmarshal([]byte(content[start:end+1]), &report)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding review response: %w", err)
+	}
+	if report.Findings == nil {
+		report.Findings = []Finding{}
+	}
+	return &report, nil
+}
+
+// Validate normalizes the findings and returns the problems of the findings
+// that are invalid, such as a missing file or an unknown severity
+func (r *Report) Validate() []string {
+	var problems []string
+	for i := range r.Findings {
+		finding := &r.Findings[i]
+		finding.File = strings.TrimPrefix(strings.TrimSpace(finding.File), "b/")
+		finding.Severity = normalize(finding.Severity)
+		finding.Category = normalize(finding.Category)
+		finding.Explanation = strings.TrimSpace(finding.Explanation)
+		finding.Fix = strings.TrimSpace(finding.Fix)
+		if finding.EndLine < finding.StartLine {
+			finding.EndLine = finding.StartLine
+		}
+
+		for _, problem := range finding.problems() {
+			problems = append(problems, fmt.Sprintf("finding %d: %s", i+1, problem))
+		}
+	}
+	return problems
+}
+
+// DropInvalid removes the findings that do not pass validation
+func (r *Report) DropInvalid() {
+	valid := []Finding{}
+	for _, finding := range r.Findings {
+		if len(finding.problems()) == 0 {
+			valid = append(valid, finding)
+		}
+	}
+	r.Findings = valid
+}
+
+func (f Finding) problems() []string {
+	var problems []string
+	if f.File == "" {
+		problems = append(problems, "file is missing")
+	}
+	if f.StartLine < 0 {
+		problems = append(problems, fmt.Sprintf("start_line %d is negative", f.StartLine))
+	}
+	if SeverityRank(f.Severity) < 0 {
+		problems = append(problems, fmt.Sprintf("severity %q is not one of %s", f.Severity, strings.Join(Severities, ", ")))
+	}
+	if !contains(Categories, f.Category) {
+		problems = append(problems, fmt.Sprintf("category %q is not one of %s", f.Category, strings.Join(Categories, ", ")))
+	}
+	if f.Explanation == "" {
+		problems = append(problems, "explanation is missing")
+	}
+	return problems
+}
+
+// SeverityRank returns the position of a severity from most severe, or -1 when unknown
+func SeverityRank(severity string) int {
+	for i, s := range Severities {
+		if s == severity {
+			return i
+		}
+	}
+	return -1
+}
+
+// Location returns "file:line" or "file:start-end" for display
+func (f Finding) Location() string {
+	switch {
+	case f.StartLine == 0:
+		return f.File
+	case f.EndLine > f.StartLine:
+		return fmt.Sprintf("%s:%d-%d", f.File, f.StartLine, f.EndLine)
+	default:
+		return fmt.Sprintf("%s:%d", f.File, f.StartLine)
+	}
+}
+
+func normalize(value string) string {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if alias, ok := aliases[value]; ok {
+		return alias
+	}
+	return value
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Fingerprint identifies a finding across runs. It is based on the file,
+// category and code of the finding rather than the wording of the model, and
+// on the cited line when there is no code snippet.
+func (f Finding) Fingerprint() string {
+	location := strconv.Itoa(f.StartLine)
+	if f.Snippet != "" {
+		var code []string
+		for _, line := range strings.Split(f.Snippet, "\n") {
+			code = append(code, strings.TrimSpace(line))
+		}
+		location = strings.Join(code, "\n")
+	}
+
+	sum := sha256.Sum256([]byte(f.File + "\n" + f.Category + "\n" + location))
+	return hex.EncodeToString(sum[:6])
+}
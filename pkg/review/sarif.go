@@ -0,0 +1,196 @@
+package review
+
+import (
+	"encoding/json"
+	"fmt"
+	"runtime/debug"
+	"strings"
+)
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+	// sarifFingerprint names the partial fingerprint, versioned so it can change later
+	sarifFingerprint = "gommitFinding/v1"
+)
+
+// ruleDescriptions describes the rule created for each category
+var ruleDescriptions = map[string]string{
+	CategoryBug:         "Incorrect behavior, crashes or logic errors",
+	CategorySecurity:    "Vulnerabilities and unsafe handling of data or credentials",
+	CategoryPerformance: "Unnecessary work, allocations or slow algorithms",
+	CategoryStyle:       "Readability, naming and maintainability",
+	CategoryTest:        "Missing, wrong or fragile tests",
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string             `json:"id"`
+	Name                 string             `json:"name"`
+	ShortDescription     sarifMessage       `json:"shortDescription"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+	Properties           sarifProperties    `json:"properties"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifProperties struct {
+	Tags []string `json:"tags"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID              string            `json:"ruleId"`
+	RuleIndex           int               `json:"ruleIndex"`
+	Level               string            `json:"level"`
+	Message             sarifMessage      `json:"message"`
+	Locations           []sarifLocation   `json:"locations"`
+	PartialFingerprints map[string]string `json:"partialFingerprints"`
+	Properties          map[string]any    `json:"properties"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId"`
+}
+
+type sarifRegion struct {
+	StartLine int           `json:"startLine"`
+	EndLine   int           `json:"endLine"`
+	Snippet   *sarifMessage `json:"snippet,omitempty"`
+}
+
+// SARIF renders the report as a SARIF 2.1.0 log with one rule per category,
+// so the findings can be ingested by code scanning dashboards
+func (r *Report) SARIF() (string, error) {
+	driver := sarifDriver{
+		Name:           "gommit",
+		Version:        toolVersion(),
+		InformationURI: "https://github.com/alexandrocuma/gommit",
+	}
+	ruleIndex := make(map[string]int)
+	for i, category := range Categories {
+		ruleIndex[category] = i
+		driver.Rules = append(driver.Rules, sarifRule{
+			ID:                   ruleID(category),
+			Name:                 strings.ToUpper(category[:1]) + category[1:],
+			ShortDescription:     sarifMessage{Text: ruleDescriptions[category]},
+			DefaultConfiguration: sarifConfiguration{Level: "warning"},
+			Properties:           sarifProperties{Tags: []string{category}},
+		})
+	}
+
+	results := []sarifResult{}
+	for _, finding := range r.Findings {
+		message := finding.Explanation
+		if finding.Fix != "" {
+			message += "\n\nSuggested fix: " + finding.Fix
+		}
+
+		location := sarifPhysicalLocation{
+			ArtifactLocation: sarifArtifactLocation{URI: finding.File, URIBaseID: "%SRCROOT%"},
+		}
+		if finding.StartLine > 0 {
+			location.Region = &sarifRegion{StartLine: finding.StartLine, EndLine: finding.EndLine}
+			if code := snippetCode(finding.Snippet); code != "" {
+				location.Region.Snippet = &sarifMessage{Text: code}
+			}
+		}
+
+		results = append(results, sarifResult{
+			RuleID:              ruleID(finding.Category),
+			RuleIndex:           ruleIndex[finding.Category],
+			Level:               sarifLevel(finding.Severity),
+			Message:             sarifMessage{Text: message},
+			Locations:           []sarifLocation{{PhysicalLocation: location}},
+			PartialFingerprints: map[string]string{sarifFingerprint: finding.Fingerprint()},
+			Properties: map[string]any{
+				"severity": finding.Severity,
+				"anchored": finding.Anchored,
+			},
+		})
+	}
+
+	log := sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs:    []sarifRun{{Tool: sarifTool{Driver: driver}, Results: results}},
+	}
+	content, err := json.MarshalIndent(log, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("error encoding SARIF output: %w", err)
+	}
+	return string(content) + "\n", nil
+}
+
+func ruleID(category string) string {
+	return "gommit/" + category
+}
+
+// sarifLevel maps a severity to the SARIF result levels error, warning and note
+func sarifLevel(severity string) string {
+	switch severity {
+	case SeverityCritical, SeverityHigh:
+		return "error"
+	case SeverityMedium:
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+// snippetCode strips the diff markers from a finding snippet
+func snippetCode(snippet string) string {
+	var lines []string
+	for _, line := range strings.Split(strings.TrimSuffix(snippet, "\n"), "\n") {
+		if line != "" && line != "…" {
+			line = line[1:]
+		}
+		lines = append(lines, line)
+	}
+	return strings.Trim(strings.Join(lines, "\n"), "\n")
+}
+
+// toolVersion returns the module version gommit was built from, if known
+func toolVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok || info.Main.Version == "(devel)" {
+		return ""
+	}
+	return info.Main.Version
+}
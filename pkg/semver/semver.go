@@ -0,0 +1,126 @@
+package semver
+
+import (
+	"cmp"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Bump levels
+const (
+	Major = "major"
+	Minor = "minor"
+	Patch = "patch"
+)
+
+var versionPattern = regexp.MustCompile(`^(v?)(\d+)\.(\d+)\.(\d+)(?:-([0-9A-Za-z.-]+))?(?:\+[0-9A-Za-z.-]+)?$`)
+
+// Version is a semantic version, optionally with a v prefix as used in git tags
+type Version struct {
+	Prefix     string
+	Major      int
+	Minor      int
+	Patch      int
+	Prerelease string
+}
+
+// Parse parses a version such as v1.2.3 or 1.2.3-rc.1. It reports false when
+// the text is not a semantic version.
+func Parse(text string) (Version, bool) {
+	match := versionPattern.FindStringSubmatch(text)
+	if match == nil {
+		return Version{}, false
+	}
+
+	major, _ := strconv.Atoi(match[2])
+	minor, _ := strconv.Atoi(match[3])
+	patch, _ := strconv.Atoi(match[4])
+	return Version{Prefix: match[1], Major: major, Minor: minor, Patch: patch, Prerelease: match[5]}, true
+}
+
+func (v Version) String() string {
+	s := fmt.Sprintf("%s%d.%d.%d", v.Prefix, v.Major, v.Minor, v.Patch)
+	if v.Prerelease != "" {
+		s += "-" + v.Prerelease
+	}
+	return s
+}
+
+// Bump returns the next version for a bump level. A prerelease is released as
+// its own version when the bump does not go beyond it, e.g. 2.0.0-rc.1 becomes 2.0.0.
+func (v Version) Bump(level string) Version {
+	next := Version{Prefix: v.Prefix, Major: v.Major, Minor: v.Minor, Patch: v.Patch}
+	if v.Prerelease != "" {
+		switch {
+		case level == Patch,
+			level == Minor && v.Patch == 0,
+			level == Major && v.Patch == 0 && v.Minor == 0:
+			return next
+		}
+	}
+
+	switch level {
+	case Major:
+		next.Major++
+		next.Minor, next.Patch = 0, 0
+	case Minor:
+		next.Minor++
+		next.Patch = 0
+	default:
+		next.Patch++
+	}
+	return next
+}
+
+// Compare returns -1, 0 or 1 when v is lower, equal or higher than other.
+// Prereleases sort before their release.
+func (v Version) Compare(other Version) int {
+	for _, pair := range [][2]int{{v.Major, other.Major}, {v.Minor, other.Minor}, {v.Patch, other.Patch}} {
+		if pair[0] != pair[1] {
+			if pair[0] < pair[1] {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	switch {
+	case v.Prerelease == other.Prerelease:
+		return 0
+	case v.Prerelease == "":
+		return 1
+	case other.Prerelease == "":
+		return -1
+	default:
+		return comparePrerelease(v.Prerelease, other.Prerelease)
+	}
+}
+
+// comparePrerelease compares dot-separated prerelease identifiers one by one:
+// numeric identifiers numerically and below alphanumeric ones, which compare
+// as text, and a shorter prerelease is lower when all its identifiers match,
+// e.g. rc.2 < rc.10 < rc.10.1
+func comparePrerelease(a, b string) int {
+	left, right := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(left) && i < len(right); i++ {
+		leftNumber, leftErr := strconv.ParseUint(left[i], 10, 64)
+		rightNumber, rightErr := strconv.ParseUint(right[i], 10, 64)
+		switch {
+		case leftErr == nil && rightErr == nil:
+			if leftNumber != rightNumber {
+				return cmp.Compare(leftNumber, rightNumber)
+			}
+		case leftErr == nil:
+			return -1
+		case rightErr == nil:
+			return 1
+		default:
+			if c := strings.Compare(left[i], right[i]); c != 0 {
+				return c
+			}
+		}
+	}
+	return cmp.Compare(len(left), len(right))
+}
@@ -0,0 +1,318 @@
+package style
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/alexandrocuma/gommit/internal/git"
+	"github.com/alexandrocuma/gommit/pkg/conventional"
+	"github.com/alexandrocuma/gommit/pkg/ticket"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// ProfileFile is the profile location relative to the repository git directory,
+// keeping it out of the working tree and per clone
+const ProfileFile = "gommit/style.yaml"
+
+// Ticket placements detected in commit subjects
+const (
+	TicketNone   = "none"
+	TicketPrefix = "prefix" // JIRA-123 Fix login
+	TicketScope  = "scope"  // fix(JIRA-123): login
+	TicketSuffix = "suffix" // Fix login (JIRA-123)
+	TicketInline = "inline" // Fix JIRA-123 login
+)
+
+var gitmojiShortcode = regexp.MustCompile(`^:[a-z0-9_+-]+:`)
+
+// Count is a value seen in commit messages and how often it appeared
+type Count struct {
+	Value string `yaml:"value"`
+	Count int    `yaml:"count"`
+}
+
+// Profile describes the commit message conventions of a repository
+type Profile struct {
+	AnalyzedCommits      int       `yaml:"analyzed_commits"`
+	UpdatedAt            time.Time `yaml:"updated_at"`
+	Conventional         float64   `yaml:"conventional"` // Share of commits following Conventional Commits
+	Types                []Count   `yaml:"types,omitempty"`
+	Scopes               []Count   `yaml:"scopes,omitempty"`
+	Emoji                float64   `yaml:"emoji"`   // Share of subjects containing an emoji
+	Gitmoji              float64   `yaml:"gitmoji"` // Share of subjects starting with an emoji or :shortcode:
+	Capitalized          float64   `yaml:"capitalized"`
+	TrailingPeriod       float64   `yaml:"trailing_period"`
+	AverageSubjectLength int       `yaml:"average_subject_length"`
+	MaxSubjectLength     int       `yaml:"max_subject_length"` // 90th percentile, ignoring outliers
+	WithBody             float64   `yaml:"with_body"`
+	TicketPlacement      string    `yaml:"ticket_placement"`
+	TicketExample        string    `yaml:"ticket_example,omitempty"`
+	Summary              string    `yaml:"summary,omitempty"` // Optional AI-written summary of the style
+}
+
+// Analyze derives a style profile from commit messages using heuristics
+func Analyze(commits []git.Commit) *Profile {
+	profile := &Profile{
+		AnalyzedCommits: len(commits),
+		UpdatedAt:       time.Now(),
+		TicketPlacement: TicketNone,
+	}
+	if len(commits) == 0 {
+		return profile
+	}
+
+	types := make(map[string]int)
+	scopes := make(map[string]int)
+	placements := make(map[string]int)
+	var lengths []int
+	var conventionalCount, emojiCount, gitmojiCount, capitalizedCount, periodCount, bodyCount int
+
+	for _, commit := range commits {
+		subject := strings.TrimSpace(commit.Subject)
+		lengths = append(lengths, utf8.RuneCountInString(subject))
+
+		description := subject
+		parsed, ok := conventional.Parse(subject, commit.Body)
+		if ok && (conventional.IsKnownType(parsed.Type) || parsed.Scope != "") {
+			conventionalCount++
+			types[parsed.Type]++
+			if parsed.Scope != "" && !ticket.Pattern.MatchString(parsed.Scope) {
+				scopes[parsed.Scope]++
+			}
+			description = parsed.Description
+		}
+
+		if startsWithEmoji(subject) || gitmojiShortcode.MatchString(subject) {
+			gitmojiCount++
+		}
+		if containsEmoji(subject) || gitmojiShortcode.MatchString(subject) {
+			emojiCount++
+		}
+
+		first, _ := utf8.DecodeRuneInString(stripTicket(description))
+		if unicode.IsUpper(first) {
+			capitalizedCount++
+		}
+		if strings.HasSuffix(subject, ".") {
+			periodCount++
+		}
+		if commit.Body != "" {
+			bodyCount++
+		}
+
+		placement, ref := ticketPlacement(subject, parsed.Scope)
+		if placement != TicketNone {
+			placements[placement]++
+			if profile.TicketExample == "" {
+				profile.TicketExample = ref
+			}
+		}
+	}
+
+	total := float64(len(commits))
+	profile.Conventional = float64(conventionalCount) / total
+	profile.Emoji = float64(emojiCount) / total
+	profile.Gitmoji = float64(gitmojiCount) / total
+	profile.Capitalized = float64(capitalizedCount) / total
+	profile.TrailingPeriod = float64(periodCount) / total
+	profile.WithBody = float64(bodyCount) / total
+	profile.Types = topCounts(types, 8)
+	profile.Scopes = topCounts(scopes, 10)
+
+	sum := 0
+	for _, length := range lengths {
+		sum += length
+	}
+	profile.AverageSubjectLength = sum / len(lengths)
+	sort.Ints(lengths)
+	profile.MaxSubjectLength = lengths[(len(lengths)*9)/10]
+
+	// Ticket references must appear in at least a fifth of the commits to count
+	best := topCounts(placements, 1)
+	if len(best) > 0 && float64(best[0].Count)/total >= 0.2 {
+		profile.TicketPlacement = best[0].Value
+	}
+
+	return profile
+}
+
+// PromptSection renders the profile as instructions for the commit prompt
+func (p *Profile) PromptSection() string {
+	if p == nil || p.AnalyzedCommits == 0 {
+		return ""
+	}
+
+	var rules []string
+	if p.Conventional >= 0.5 {
+		rule := "Use Conventional Commits (type(scope): description)"
+		if len(p.Types) > 0 {
+			rule += ", common types: " + joinValues(p.Types)
+		}
+		rules = append(rules, rule)
+		if len(p.Scopes) > 0 {
+			rules = append(rules, "Common scopes: "+joinValues(p.Scopes))
+		}
+	} else {
+		rules = append(rules, "Do not use Conventional Commit type prefixes")
+	}
+
+	switch {
+	case p.Gitmoji >= 0.5:
+		rules = append(rules, "Start the subject with a gitmoji that matches the change")
+	case p.Emoji < 0.1:
+		rules = append(rules, "Do not use emoji")
+	}
+
+	if p.Capitalized >= 0.5 {
+		rules = append(rules, "Capitalize the first word of the description")
+	} else {
+		rules = append(rules, "Start the description with a lowercase letter")
+	}
+
+	if p.TrailingPeriod < 0.5 {
+		rules = append(rules, "Do not end the subject with a period")
+	}
+
+	rules = append(rules, fmt.Sprintf("Keep the subject around %d characters, at most %d", p.AverageSubjectLength, p.MaxSubjectLength))
+
+	if p.TicketPlacement != TicketNone {
+		rules = append(rules, fmt.Sprintf("Reference the ticket as a %s, e.g. %s, when the branch or changes mention one", p.TicketPlacement, p.TicketExample))
+	}
+
+	section := fmt.Sprintf("Repository commit style (learned from %d commits):\n- %s", p.AnalyzedCommits, strings.Join(rules, "\n- "))
+	if p.Summary != "" {
+		section += "\n\n" + p.Summary
+	}
+	return section
+}
+
+// ProfilePath returns the location of the style profile for a git directory
+func ProfilePath(gitDir string) string {
+	return filepath.Join(gitDir, ProfileFile)
+}
+
+// Save writes the profile to the given path, creating parent directories
+func Save(path string, profile *Profile) error {
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return fmt.Errorf("error creating style directory: %w", err)
+	}
+
+	content, err := yaml.Marshal(profile)
+	if err != nil {
+		return fmt.Errorf("error encoding style profile: %w", err)
+	}
+
+	err = os.WriteFile(path, content, 0644)
+	if err != nil {
+		return fmt.Errorf("error writing style profile %q: %w", path, err)
+	}
+	return nil
+}
+
+// Load reads a profile from the given path. It returns nil without error
+// when the repository has no profile yet.
+func Load(path string) (*Profile, error) {
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading style profile %q: %w", path, err)
+	}
+
+	var profile Profile
+	err = yaml.Unmarshal(content, &profile)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing style profile %q: %w", path, err)
+	}
+	return &profile, nil
+}
+
+// ticketPlacement finds where a ticket reference appears in a subject
+func ticketPlacement(subject, scope string) (string, string) {
+	if scope != "" && ticket.Pattern.MatchString(scope) {
+		return TicketScope, ticket.Pattern.FindString(scope)
+	}
+
+	loc := ticket.Pattern.FindStringIndex(subject)
+	if loc == nil {
+		return TicketNone, ""
+	}
+	ref := subject[loc[0]:loc[1]]
+
+	before := strings.Trim(subject[:loc[0]], " [(")
+	after := strings.Trim(subject[loc[1]:], " ])):.")
+	switch {
+	case before == "" || startsWithEmoji(before) && utf8.RuneCountInString(before) == 1:
+		return TicketPrefix, ref
+	case after == "":
+		return TicketSuffix, ref
+	default:
+		return TicketInline, ref
+	}
+}
+
+// stripTicket removes a leading ticket reference so capitalization is checked on the text
+func stripTicket(text string) string {
+	// Bracketed tags such as "[api]" or "[JIRA-123]" are not part of the sentence
+	if strings.HasPrefix(text, "[") {
+		if end := strings.Index(text, "]"); end != -1 {
+			text = strings.TrimSpace(text[end+1:])
+		}
+	}
+	text = strings.TrimLeft(text, " [(")
+	loc := ticket.Pattern.FindStringIndex(text)
+	if loc != nil && loc[0] == 0 {
+		text = strings.TrimLeft(text[loc[1]:], " ]):-")
+	}
+	return text
+}
+
+// isEmoji reports whether r belongs to the common emoji and pictograph blocks
+func isEmoji(r rune) bool {
+	return r >= 0x1F300 && r <= 0x1FAFF || r >= 0x2600 && r <= 0x27BF || r >= 0x2B00 && r <= 0x2BFF
+}
+
+func startsWithEmoji(text string) bool {
+	r, _ := utf8.DecodeRuneInString(text)
+	return isEmoji(r)
+}
+
+func containsEmoji(text string) bool {
+	return strings.IndexFunc(text, isEmoji) != -1
+}
+
+// topCounts returns the n most frequent values, most frequent first
+func topCounts(counts map[string]int, n int) []Count {
+	result := make([]Count, 0, len(counts))
+	for value, count := range counts {
+		result = append(result, Count{Value: value, Count: count})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Value < result[j].Value
+	})
+	if len(result) > n {
+		result = result[:n]
+	}
+	return result
+}
+
+func joinValues(counts []Count) string {
+	values := make([]string, len(counts))
+	for i, c := range counts {
+		values[i] = c.Value
+	}
+	return strings.Join(values, ", ")
+}
@@ -0,0 +1,21 @@
+package ticket
+
+import "regexp"
+
+// Pattern matches issue tracker keys such as JIRA-123 and GitHub style #123 references
+var Pattern = regexp.MustCompile(`[A-Z][A-Z0-9]+-\d+|#\d+`)
+
+// Extract returns the unique ticket references found in the texts, in order of appearance
+func Extract(texts ...string) []string {
+	seen := make(map[string]bool)
+	var tickets []string
+	for _, text := range texts {
+		for _, ticket := range Pattern.FindAllString(text, -1) {
+			if !seen[ticket] {
+				seen[ticket] = true
+				tickets = append(tickets, ticket)
+			}
+		}
+	}
+	return tickets
+}
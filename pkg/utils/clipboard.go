@@ -2,6 +2,7 @@ package utils
 
 import (
 	"fmt"
+	"os"
 	"os/exec"
 	"runtime"
 )
@@ -174,10 +175,10 @@ func getClipboardInfo() string {
 // Replace the copyToClipboardUtil function with:
 func CopyToClipboardUtil(content string) error {
 	if isClipboardAvailable() {
-		fmt.Println("✅ Clipboard support: Available")
+		fmt.Fprintln(os.Stderr, "✅ Clipboard support: Available")
 	} else {
-		fmt.Println("⚠️  Clipboard support: Not available")
-		fmt.Printf("ℹ️  %s\n", getClipboardInfo())
+		fmt.Fprintln(os.Stderr, "⚠️  Clipboard support: Not available")
+		fmt.Fprintf(os.Stderr, "ℹ️  %s\n", getClipboardInfo())
 		
 		return fmt.Errorf("clipboard not available on this system")
 	}
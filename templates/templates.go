@@ -0,0 +1,13 @@
+// Package templates embeds the PR templates shipped with gommit, so drafting
+// works before a templates directory exists, e.g. in CI
+package templates
+
+import _ "embed"
+
+// DefaultName is the name of the template used when none is chosen
+const DefaultName = "default.md"
+
+// Default is the content of default.md, used when the templates directory has no such file
+//
+//go:embed default.md
+var Default string